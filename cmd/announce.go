@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/konflux"
+	"github.com/sebsoto/gojira/pkg/release"
+)
+
+var (
+	dryRun bool
+	// announceCmd represents the announce command
+	announceCmd = &cobra.Command{
+		Use:   "announce <release>",
+		Short: "Comments on the issues shipped by a Konflux Release",
+		Long: `Once the given Konflux Release has been released, posts a comment on each Jira issue listed in its release notes
+with the version, snapshot, image digests and links to the Release and its advisory. The comment is rendered from the
+announcement template. Issues already announced on are skipped, or have their comment updated if it changed, so the
+command can be rerun.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			rel, err := konflux.GetRelease(context.Background(), namespace, args[0])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if result := rel.Result(); result != konflux.ResultSucceeded {
+				fmt.Fprintf(os.Stderr, "Release %s has not been released: %s\n", rel.Name, result)
+				os.Exit(1)
+			}
+			if len(rel.Issues) == 0 {
+				fmt.Printf("Release %s lists no issues\n", rel.Name)
+				return
+			}
+			link, err := konflux.ReleaseURL(product.Konflux.ReleaseURL, namespace, rel.Name, rel.Application)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			announcement := release.NewAnnouncement(product.DisplayName, version, namespace, link, rel)
+			if err = announcement.Post(rel.Issues, dryRun); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	releaseCmd.AddCommand(announceCmd)
+	announceCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
+	announceCmd.Flags().StringVar(&version, "version", "", "Semver of the release")
+	announceCmd.MarkFlagRequired("version")
+	announceCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the comment without posting it")
+}
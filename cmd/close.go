@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/konflux"
+	"github.com/sebsoto/gojira/pkg/release"
+)
+
+var (
+	epic string
+	// closeCmd represents the close command
+	closeCmd = &cobra.Command{
+		Use:   "close <release>",
+		Short: "Closes the Jira issues of a shipped release",
+		Long: `Once the given Konflux Release has been released, closes the release task and epic, and moves the Jira issues
+listed in the Release's notes to the statuses given by the product's closing settings. By default ON_QA issues are moved
+to Release Pending, and Verified issues are closed. Issues already moved are skipped, so the command can be rerun.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			rel, err := konflux.GetRelease(context.Background(), namespace, args[0])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if result := rel.Result(); result != konflux.ResultSucceeded {
+				fmt.Fprintf(os.Stderr, "Release %s has not been released: %s\n", rel.Name, result)
+				os.Exit(1)
+			}
+			comment := fmt.Sprintf("Shipped by Konflux Release %s/%s of snapshot %s", namespace, rel.Name, rel.Snapshot)
+			if err = release.CloseRelease(product, issue, epic, rel.Issues, comment); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	releaseCmd.AddCommand(closeCmd)
+	closeCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
+	closeCmd.Flags().StringVar(&issue, "issue", "", "Release task to close")
+	closeCmd.MarkFlagRequired("issue")
+	closeCmd.Flags().StringVar(&epic, "epic", "", "Release epic to close, defaults to the epic of the release task")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/jira"
+	"github.com/sebsoto/gojira/pkg/konflux"
+)
+
+var (
+	assumeYes bool
+	// createCmd represents the create command
+	createCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Creates the Konflux Release in the cluster",
+		Long: `Generates the Konflux Release for the given version, as shown by the status command, and creates it in the
+cluster after confirmation. If a release task is given, a link to the created Release is added to it.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			opts, err := releaseOptions(tailCommit)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			rel, err := konflux.NewRelease(namespace, releaseplan, version, product.Projects(), opts)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			rel.PrintContents()
+			if err = rel.CheckTests(force); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			fmt.Println("The following Release will be created:")
+			for _, line := range strings.Split(strings.TrimSpace(rel.ReleaseYAML()), "\n") {
+				fmt.Printf("+ %s\n", line)
+			}
+			if !assumeYes && !confirm("Create this Release?") {
+				fmt.Println("Aborted")
+				return
+			}
+			if err = rel.Create(context.Background()); err != nil {
+				fmt.Fprintf(os.Stderr, "error creating release: %s\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Created Release %s/%s\n", rel.Release.GetNamespace(), rel.Release.GetName())
+			if issue == "" {
+				return
+			}
+			link, err := rel.URL(product.Konflux.ReleaseURL)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if err = jira.AddRemoteLink(issue, link, "Konflux Release "+rel.Release.GetName()); err != nil {
+				fmt.Fprintf(os.Stderr, "error linking release to %s: %s\n", issue, err)
+				os.Exit(1)
+			}
+			fmt.Printf("Linked Release to %s\n", issue)
+		},
+	}
+)
+
+// confirm asks the user the given yes or no question, returning true if they answered yes
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func init() {
+	releaseCmd.AddCommand(createCmd)
+	createCmd.Flags().StringVar(&releaseplan, "releaseplan", "", "Konflux releaseplan, defaults to the product's releasePlan")
+	createCmd.Flags().StringVar(&version, "version", "", "Semver of the release")
+	createCmd.MarkFlagRequired("version")
+	createCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
+	createCmd.Flags().StringVar(&tailCommit, "tail", "", "tail commit of the release")
+	createCmd.Flags().StringVar(&issue, "issue", "", "Release task to link the created Release to")
+	createCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Create the Release without asking for confirmation")
+}
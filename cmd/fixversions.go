@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/pkg/konflux"
+	"github.com/sebsoto/gojira/pkg/release"
+)
+
+var (
+	apply bool
+	// fixVersionsCmd represents the fixversions command
+	fixVersionsCmd = &cobra.Command{
+		Use:   "fixversions",
+		Short: "Checks the fix versions of the issues included in a release",
+		Long: `Reports the Jira issues included in the release, as shown by the status command, whose fix versions do not include
+the release's version, named by the product's versionName. With --apply, the release's version is added to the fix
+versions of each issue in the product's project (--project), creating the version first if needed. Issues of other projects are
+only reported. Exits with 1 if any issue is left with a missing or wrong fix version.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			expected, err := config.Render(product.VersionName, version)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			opts, err := releaseOptions(tailCommit)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			rel, err := konflux.NewRelease(namespace, releaseplan, version, product.Projects(), opts)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if err = rel.CheckTests(force); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			mismatches := release.CheckFixVersions(rel.Issues, expected)
+			if len(mismatches) == 0 {
+				fmt.Printf("All %d issues have fix version %s\n", len(rel.Issues), expected)
+				return
+			}
+			fmt.Printf("%d of %d issues do not have fix version %s:\n", len(mismatches), len(rel.Issues), expected)
+			w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+			fmt.Fprintln(w, "Issue\tFix Versions")
+			fmt.Fprintln(w, "___\t___")
+			for _, mismatch := range mismatches {
+				current := strings.Join(mismatch.Current, ", ")
+				if current == "" {
+					current = "(none)"
+				}
+				fmt.Fprintf(w, "%s\t%s\n", mismatch.Key, current)
+			}
+			w.Flush()
+			if !apply {
+				os.Exit(1)
+			}
+			if err = release.ApplyFixVersion(mismatches, expected, project); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	releaseCmd.AddCommand(fixVersionsCmd)
+	fixVersionsCmd.Flags().StringVar(&releaseplan, "releaseplan", "", "Konflux releaseplan, defaults to the product's releasePlan")
+	fixVersionsCmd.Flags().StringVar(&version, "version", "", "Semver of the release")
+	fixVersionsCmd.MarkFlagRequired("version")
+	fixVersionsCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
+	fixVersionsCmd.Flags().StringVar(&tailCommit, "tail", "", "tail commit of the release")
+	fixVersionsCmd.Flags().BoolVar(&apply, "apply", false, "Set the fix version of the reported issues")
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/konflux"
+)
+
+var (
+	application  string
+	releasePlans []string
+	// historyCmd represents the history command
+	historyCmd = &cobra.Command{
+		Use:   "history",
+		Short: "Lists past Konflux Releases",
+		Long: `Lists the Konflux Releases of an application, grouped by ReleasePlan, with the snapshot and commit released, the
+versions tagged at that commit, the result of the Release, and the Jira issues listed in its release notes.`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			setFlagDefault(cmd, "application", product.Konflux.Application)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := validateOutput(output); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if application == "" && len(releasePlans) == 0 {
+				fmt.Fprintln(os.Stderr, "either --application or --releaseplan must be given")
+				os.Exit(1)
+			}
+			history, err := konflux.ReleaseHistory(context.Background(), namespace, application, releasePlans)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if output != outputText {
+				if err = printStructured(output, history); err != nil {
+					fmt.Fprintln(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+				return
+			}
+			printHistory(history)
+		},
+	}
+)
+
+// printHistory prints a table of Releases for each ReleasePlan
+func printHistory(history []konflux.HistoryEntry) {
+	var w *tabwriter.Writer
+	for i, entry := range history {
+		if i == 0 || history[i-1].ReleasePlan != entry.ReleasePlan {
+			if w != nil {
+				w.Flush()
+				fmt.Printf("-----\n\n")
+			}
+			fmt.Printf("ReleasePlan: %s\n", entry.ReleasePlan)
+			w = tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+			fmt.Fprintln(w, "Release\tCreated\tSnapshot\tCommit\tVersion\tResult\tIssues")
+			fmt.Fprintln(w, "___\t___\t___\t___\t___\t___\t___")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", entry.Name, entry.Created.Format("2006-01-02 15:04"),
+			entry.Snapshot, entry.Commit, strings.Join(entry.Versions, ", "), entry.Result(),
+			strings.Join(entry.Issues, ", "))
+	}
+	if w != nil {
+		w.Flush()
+		fmt.Printf("-----\n\n")
+	}
+}
+
+func init() {
+	releaseCmd.AddCommand(historyCmd)
+	historyCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
+	historyCmd.Flags().StringVar(&application, "application", "",
+		"Konflux application whose Releases are listed, defaults to the product's application or that of the first releaseplan")
+	historyCmd.Flags().StringSliceVar(&releasePlans, "releaseplan", nil,
+		"Only list Releases of the given ReleasePlans")
+	historyCmd.Flags().StringVarP(&output, "output", "o", outputText, "Output format: text, json or yaml")
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/git"
+	"github.com/sebsoto/gojira/pkg/konflux"
+)
+
+var (
+	gitURL string
+	// issueCmd represents the issue command
+	issueCmd = &cobra.Command{
+		Use:   "issue",
+		Short: "inspect Jira issues",
+		Long:  `Inspect Jira issues`,
+	}
+	// whereCmd represents the issue where command
+	whereCmd = &cobra.Command{
+		Use:   "where <key>",
+		Short: "finds the releases which shipped an issue",
+		Long: `Finds the first Konflux Release of each ReleasePlan listing the issue in its release notes. If no Release lists
+it, the commit history of the application's repository is searched instead, and the first release tag whose changes
+mention the issue is reported. Exits with 1 if the issue has not been released.`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			setFlagDefault(cmd, "namespace", product.Konflux.Namespace)
+			setFlagDefault(cmd, "application", product.Konflux.Application)
+			return requireFlags(cmd, "namespace", "application")
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := validateOutput(output); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			where, err := findIssue(args[0])
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if output != outputText {
+				if err = printStructured(output, where); err != nil {
+					fmt.Fprintln(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+			} else {
+				where.print()
+			}
+			if len(where.Releases) == 0 && where.Tag == nil {
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+// issueLocation describes where an issue was shipped
+type issueLocation struct {
+	Key      string            `json:"key"`
+	Releases []releaseLocation `json:"releases"`
+	// Tag is the first release tag containing the issue, found when no Release lists it
+	Tag *tagLocation `json:"tag,omitempty"`
+}
+
+type releaseLocation struct {
+	Release     string `json:"release"`
+	ReleasePlan string `json:"releasePlan"`
+	// Environment is prod or stage, if the ReleasePlan is one of the product's
+	Environment string    `json:"environment"`
+	Date        time.Time `json:"date"`
+	Result      string    `json:"result"`
+	Versions    []string  `json:"versions"`
+}
+
+type tagLocation struct {
+	Tag    string    `json:"tag"`
+	GitURL string    `json:"gitURL"`
+	Commit string    `json:"commit"`
+	Date   time.Time `json:"date"`
+}
+
+// findIssue looks for the issue in the release notes of the application's Releases, falling back to the history of
+// its repository
+func findIssue(key string) (*issueLocation, error) {
+	history, err := konflux.ReleaseHistory(context.Background(), namespace, application, nil)
+	if err != nil {
+		return nil, err
+	}
+	where := &issueLocation{Key: key, Releases: []releaseLocation{}}
+	for _, entry := range konflux.ReleasesFixing(history, key) {
+		where.Releases = append(where.Releases, releaseLocation{
+			Release:     entry.Name,
+			ReleasePlan: entry.ReleasePlan,
+			Environment: product.ReleasePlanEnvironment(entry.ReleasePlan),
+			Date:        entry.Created,
+			Result:      entry.Result(),
+			Versions:    entry.Versions,
+		})
+	}
+	if len(where.Releases) > 0 {
+		return where, nil
+	}
+
+	repoURL := gitURL
+	if repoURL == "" {
+		var latest time.Time
+		for _, entry := range history {
+			if entry.GitURL != "" && entry.Created.After(latest) {
+				repoURL, latest = entry.GitURL, entry.Created
+			}
+		}
+	}
+	if repoURL == "" {
+		return nil, fmt.Errorf("%s is not listed by any Release, and no repository is known to search, use --git-url",
+			key)
+	}
+	fmt.Fprintf(os.Stderr, "%s is not listed by any Release, searching the history of %s\n", key, repoURL)
+	repo, err := git.NewRepo(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	tag, commit, err := git.FirstTagContaining(repo, git.Mentions(key))
+	if err != nil {
+		return nil, err
+	}
+	if tag != nil {
+		where.Tag = &tagLocation{Tag: tag.Name, GitURL: repoURL, Commit: commit.SHA, Date: commit.Date}
+	}
+	return where, nil
+}
+
+func (l *issueLocation) print() {
+	if len(l.Releases) == 0 && l.Tag == nil {
+		fmt.Printf("%s has not been released\n", l.Key)
+		return
+	}
+	if l.Tag != nil {
+		fmt.Printf("%s was first released in %s, merged in %s on %s\n", l.Key, l.Tag.Tag, l.Tag.Commit,
+			l.Tag.Date.Format(time.DateOnly))
+		return
+	}
+	fmt.Printf("%s was released by:\n", l.Key)
+	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "Release\tReleasePlan\tEnvironment\tDate\tResult\tVersion")
+	fmt.Fprintln(w, "___\t___\t___\t___\t___\t___")
+	for _, r := range l.Releases {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Release, r.ReleasePlan, r.Environment,
+			r.Date.Format("2006-01-02 15:04"), r.Result, strings.Join(r.Versions, ", "))
+	}
+	w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(issueCmd)
+	issueCmd.AddCommand(whereCmd)
+	whereCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
+	whereCmd.Flags().StringVar(&application, "application", "",
+		"Konflux application whose Releases are searched, defaults to the product's application")
+	whereCmd.Flags().StringVar(&gitURL, "git-url", "",
+		"Repository searched when no Release lists the issue, defaults to that of the most recent Release")
+	whereCmd.Flags().StringVar(&git.DefaultRepoOptions.LocalPath, "git-path", "",
+		"Local checkout of the repository searched when no Release lists the issue")
+	whereCmd.Flags().StringVarP(&output, "output", "o", outputText, "Output format: text, json or yaml")
+}
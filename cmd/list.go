@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -15,7 +16,11 @@ var listCmd = &cobra.Command{
 	Short: "lists pending releases",
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
-		issues, err := jira.Search(fmt.Sprintf("project = %s AND issuetype = Epic AND labels in (OperatorProductization) AND statusCategory != \"Done\"", project))
+		query := fmt.Sprintf("project = %s AND issuetype = Epic AND statusCategory != \"Done\"", project)
+		if len(product.Labels) > 0 {
+			query += fmt.Sprintf(" AND labels in (%s)", strings.Join(product.Labels, ","))
+		}
+		issues, err := jira.Search(query)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(1)
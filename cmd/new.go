@@ -34,12 +34,21 @@ var (
 				fmt.Fprintf(os.Stderr, "version is not a valid semver")
 				os.Exit(1)
 			}
-			rel, err := konflux.NewRelease(namespace, releaseplan, version, []string{project, "OCPBUGS"}, "")
+			opts, err := releaseOptions("")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n", err)
+				os.Exit(1)
+			}
+			rel, err := konflux.NewRelease(namespace, releaseplan, version, product.Projects(), opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "error creating release: %s\n", err)
 				os.Exit(1)
 			}
-			if err = release.CreateIssues(project, version, majorRelease, parsedDate, rel.Release); err != nil {
+			if err = rel.CheckTests(force); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if err = release.CreateIssues(product, version, majorRelease, parsedDate, rel.Release); err != nil {
 				fmt.Fprintf(os.Stderr, "%s", err)
 				os.Exit(1)
 			}
@@ -53,6 +62,8 @@ func init() {
 	newCmd.MarkFlagRequired("date")
 	newCmd.Flags().BoolVar(&majorRelease, "major", false, "Indicate this is a major release")
 	newCmd.MarkFlagRequired("major")
-	newCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace")
-	newCmd.MarkFlagRequired("namespace")
+	newCmd.Flags().StringVar(&version, "version", "", "Semver of the release")
+	newCmd.MarkFlagRequired("version")
+	newCmd.Flags().StringVar(&releaseplan, "releaseplan", "", "Konflux releaseplan, defaults to the product's releasePlan")
+	newCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
 }
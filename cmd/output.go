@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+// validateOutput returns an error if the given output format is not supported
+func validateOutput(format string) error {
+	switch format {
+	case outputText, outputJSON, outputYAML:
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %s, expected one of %s, %s or %s", format, outputText, outputJSON,
+		outputYAML)
+}
+
+// printStructured writes v to stdout in the given structured format
+func printStructured(format string, v interface{}) error {
+	var out []byte
+	var err error
+	switch format {
+	case outputJSON:
+		out, err = json.MarshalIndent(v, "", "  ")
+		out = append(out, '\n')
+	case outputYAML:
+		out, err = yaml.Marshal(v)
+	default:
+		return validateOutput(format)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
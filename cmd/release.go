@@ -1,22 +1,132 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/pkg/git"
+	"github.com/sebsoto/gojira/pkg/konflux"
+	"github.com/sebsoto/gojira/pkg/vuln"
+	"github.com/sebsoto/gojira/templates"
 )
 
 var (
 	project     string
 	releaseplan string
+	// gitProviders maps self-hosted git hosts to the kind of provider serving them
+	gitProviders map[string]string
+	// branchPattern, branchRule and previousBranches describe how release branches are named
+	branchPattern    string
+	branchRule       string
+	previousBranches map[string]string
+	snapshot         string
+	// force allows releasing a snapshot whose integration tests have not passed
+	force bool
+	// releaseNotesFile is a YAML fragment merged into the data of the Release
+	releaseNotesFile string
+	// vulnDB is a directory holding a copy of the Go vulnerability database
+	vulnDB string
+	// mergesOnly limits the commits included in a release to merge commits
+	mergesOnly bool
 	// releaseCmd represents the release command
 	releaseCmd = &cobra.Command{
 		Use:   "release",
 		Short: "manage releases in JIRA",
 		Long:  `Manage releases in JIRA`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			for host, kind := range gitProviders {
+				if err := git.RegisterHost(host, kind); err != nil {
+					return err
+				}
+			}
+			setFlagDefault(cmd, "project", product.JiraProject)
+			setFlagDefault(cmd, "namespace", product.Konflux.Namespace)
+			if version != "" {
+				releasePlanName, err := config.Render(product.Konflux.ReleasePlan, version)
+				if err != nil {
+					return err
+				}
+				setFlagDefault(cmd, "releaseplan", releasePlanName)
+			}
+			setFlagDefault(cmd, "branch-pattern", product.Branches.Pattern)
+			setFlagDefault(cmd, "branch-rule", product.Branches.Rule)
+			if f := cmd.Flag("previous-branch"); !f.Changed && product.Branches.Previous != nil {
+				previousBranches = product.Branches.Previous
+			}
+			if err := requireFlags(cmd, "project", "namespace", "releaseplan"); err != nil {
+				return err
+			}
+			product.JiraProject = project
+			return nil
+		},
 	}
 )
 
 func init() {
 	rootCmd.AddCommand(releaseCmd)
-	releaseCmd.PersistentFlags().StringVar(&project, "project", "", "JIRA project")
-	releaseCmd.MarkPersistentFlagRequired("project")
+	releaseCmd.PersistentFlags().StringVar(&project, "project", "", "JIRA project, defaults to the product's project")
+	releaseCmd.PersistentFlags().StringVar(&git.DefaultRepoOptions.Backend, "git-backend", git.BackendAPI,
+		"How git repositories are accessed: 'api' to use the provider's REST API, 'local' to use a cached clone")
+	releaseCmd.PersistentFlags().StringVar(&git.DefaultRepoOptions.CacheDir, "git-cache-dir", "",
+		"Directory clones are kept in when using the local git backend")
+	releaseCmd.PersistentFlags().StringVar(&git.DefaultRepoOptions.LocalPath, "git-path", "",
+		"Local checkout of the component repository, implies the local git backend. Only usable when all components "+
+			"are built from the same repository")
+	releaseCmd.PersistentFlags().StringToStringVar(&gitProviders, "git-provider", nil,
+		"Git provider serving a self-hosted host, e.g. gitlab.example.com=gitlab")
+	releaseCmd.PersistentFlags().StringVar(&branchPattern, "branch-pattern", git.DefaultBranchPattern,
+		"Regular expression matching release branches, with the changing part of the name captured in a group named version")
+	releaseCmd.PersistentFlags().StringVar(&branchRule, "branch-rule", git.PreviousRuleDecrement,
+		"How the previous release branch is derived: 'decrement' the captured version, or 'none'")
+	releaseCmd.PersistentFlags().StringToStringVar(&previousBranches, "previous-branch", nil,
+		"Explicit previous release branch, e.g. rhoso-18.0-fr2=rhoso-18.0-fr1")
+	releaseCmd.PersistentFlags().StringVar(&snapshot, "snapshot", konflux.SnapshotLatestRelease,
+		"Snapshot to release: 'latest-release' for the snapshot of the latest successful release, 'passing' for the newest "+
+			"snapshot whose integration tests passed, 'commit:<sha>' for the newest snapshot of a commit, or a snapshot name")
+	releaseCmd.PersistentFlags().BoolVar(&force, "force", false,
+		"Produce a Release even if the integration tests of the snapshot are failing or pending")
+	releaseCmd.PersistentFlags().StringVar(&releaseNotesFile, "release-notes", "",
+		"YAML file merged into the data of the Release, overriding the generated release notes")
+	releaseCmd.PersistentFlags().StringSliceVar(&templates.Dirs, "template-dir", nil,
+		"Directory searched for templates overriding the defaults")
+	releaseCmd.PersistentFlags().StringVar(&vulnDB, "vuln-db", "",
+		"Directory holding a copy of the Go vulnerability database in OSV format. If set, the Go dependencies of each "+
+			"component are checked for vulnerabilities fixed or introduced since the previous release")
+	releaseCmd.PersistentFlags().BoolVar(&mergesOnly, "merges-only", false,
+		"Only include merge commits, leaving out changes merged by squashing or rebasing")
+}
+
+// releaseOptions returns the options for generating a konflux release based on the given flags
+func releaseOptions(baseCommitOverride string) (konflux.ReleaseOptions, error) {
+	branchStrategy, err := git.NewBranchStrategy(branchPattern, branchRule, previousBranches)
+	if err != nil {
+		return konflux.ReleaseOptions{}, err
+	}
+	snapshotSelector, err := konflux.ParseSnapshotSelector(snapshot)
+	if err != nil {
+		return konflux.ReleaseOptions{}, err
+	}
+	var fragment []byte
+	if releaseNotesFile != "" {
+		if fragment, err = os.ReadFile(releaseNotesFile); err != nil {
+			return konflux.ReleaseOptions{}, err
+		}
+	}
+	var db *vuln.Database
+	if vulnDB != "" {
+		if db, err = vuln.LoadDatabase(vulnDB); err != nil {
+			return konflux.ReleaseOptions{}, err
+		}
+	}
+	return konflux.ReleaseOptions{
+		BaseCommitOverride: baseCommitOverride,
+		BranchStrategy:     branchStrategy,
+		Snapshot:           snapshotSelector,
+		Product:            product,
+		NotesFragment:      fragment,
+		VulnDB:             db,
+		MergesOnly:         mergesOnly,
+	}, nil
 }
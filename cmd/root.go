@@ -1,9 +1,22 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/pkg/git"
+	"github.com/sebsoto/gojira/pkg/jira"
+)
+
+var (
+	cfgFile     string
+	productName string
+	// cfg is the loaded configuration, and product the product selected from it
+	cfg     *config.Config
+	product *config.Product
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -19,6 +32,9 @@ to quickly create a Cobra application.`,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig(cmd)
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -30,12 +46,56 @@ func Execute() {
 	}
 }
 
+// loadConfig loads the configuration file and selects the product, applying configured values to any flags which were
+// not explicitly set
+func loadConfig(cmd *cobra.Command) error {
+	var err error
+	cfg, err = config.Load(cfgFile)
+	if err != nil {
+		return err
+	}
+	product, err = cfg.Product(productName)
+	if err != nil {
+		return err
+	}
+	setFlagDefault(cmd, "jira-url", cfg.Jira.URL)
+	for host, kind := range cfg.Git.Providers {
+		if err = git.RegisterHost(host, kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFlagDefault sets the named flag to value, if the flag exists, was not set on the command line, and value is not
+// empty
+func setFlagDefault(cmd *cobra.Command, name, value string) {
+	if f := cmd.Flag(name); f != nil && !f.Changed && value != "" {
+		f.Value.Set(value)
+	}
+}
+
+// requireFlags returns an error if any of the named flags has no value, either from the command line or configuration
+func requireFlags(cmd *cobra.Command, names ...string) error {
+	for _, name := range names {
+		if f := cmd.Flag(name); f != nil && f.Value.String() == "" {
+			return fmt.Errorf("required flag \"%s\" not set", name)
+		}
+	}
+	return nil
+}
+
 func init() {
+	// Run the persistent pre-run hooks of all parent commands, so the config is loaded for every subcommand
+	cobra.EnableTraverseRunHooks = true
+
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gojira.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gojira.yaml and ./.gojira.yaml)")
+	rootCmd.PersistentFlags().StringVar(&productName, "product", "", "Product in the config file to use")
+	rootCmd.PersistentFlags().StringVar(&jira.DefaultClient.BaseURL, "jira-url", jira.DefaultBaseURL, "Base URL of the Jira instance")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
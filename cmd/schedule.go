@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/release"
+)
+
+var (
+	holidays string
+	// scheduleCmd represents the schedule command
+	scheduleCmd = &cobra.Command{
+		Use:   "schedule",
+		Short: "Prints the planned schedule of a release",
+		Long:  `Computes the milestones of a release from its GA date, without making any changes in JIRA`,
+		Run: func(cmd *cobra.Command, args []string) {
+			parsedDate, err := time.Parse(time.DateOnly, date)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "given date has the wrong format\n")
+				os.Exit(1)
+			}
+			if holidays != "" {
+				product.Schedule.Holidays = holidays
+			}
+			schedule, err := release.ProductSchedule(product, !majorRelease, parsedDate)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			schedule.Print()
+		},
+	}
+)
+
+func init() {
+	releaseCmd.AddCommand(scheduleCmd)
+	scheduleCmd.Flags().StringVar(&date, "date", "", "Planned GA date of the release")
+	scheduleCmd.MarkFlagRequired("date")
+	scheduleCmd.Flags().BoolVar(&majorRelease, "major", false, "Indicate this is a major release")
+	scheduleCmd.Flags().StringVar(&holidays, "holidays", "", "File listing non-working days, one YYYY-MM-DD date per line")
+}
@@ -8,22 +8,56 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var namespace string
+var (
+	namespace string
+	// output is the format the status is printed in
+	output string
+)
 
 var (
 	// statusCmd represents the new command
 	statusCmd = &cobra.Command{
 		Use:   "status",
 		Short: "Current status of a potential release",
-		Long:  ``,
+		Long: `Shows the snapshot which would be released, the merges and Jira issues it includes, and the Release which
+would be created for it. With --output json or yaml, the same information is printed as a single document for use by
+automation, and all other messages are written to stderr.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			projects := []string{project, "OCPBUGS"}
-			release, err := konflux.NewRelease(namespace, releaseplan, version, projects, "")
+			if err := validateOutput(output); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			projects := product.Projects()
+			opts, err := releaseOptions("")
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err.Error())
 				os.Exit(1)
 			}
+			release, err := konflux.NewRelease(namespace, releaseplan, version, projects, opts)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if output != outputText {
+				if err = release.CheckTests(force); err != nil {
+					fmt.Fprintln(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+				report, err := release.Report()
+				if err == nil {
+					err = printStructured(output, report)
+				}
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+				return
+			}
 			release.PrintContents()
+			if err = release.CheckTests(force); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
 			fmt.Printf("\n%s\n", release.ReleaseYAML())
 		},
 	}
@@ -31,10 +65,9 @@ var (
 
 func init() {
 	releaseCmd.AddCommand(statusCmd)
-	statusCmd.Flags().StringVar(&releaseplan, "releaseplan", "", "Konflux releaseplan")
-	statusCmd.MarkFlagRequired("releaseplan")
+	statusCmd.Flags().StringVar(&releaseplan, "releaseplan", "", "Konflux releaseplan, defaults to the product's releasePlan")
 	statusCmd.Flags().StringVar(&version, "version", "", "Semver of the release")
 	statusCmd.MarkFlagRequired("version")
-	statusCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace")
-	statusCmd.MarkFlagRequired("namespace")
+	statusCmd.Flags().StringVarP(&output, "output", "o", outputText, "Output format: text, json or yaml")
+	statusCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
 }
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/templates"
+)
+
+var (
+	overwriteTemplates bool
+	// templatesCmd represents the templates command
+	templatesCmd = &cobra.Command{
+		Use:   "templates",
+		Short: "manage the templates used for release issues",
+		Long:  `Manage the templates used for the descriptions of release issues`,
+	}
+	// exportCmd represents the templates export command
+	exportCmd = &cobra.Command{
+		Use:   "export [dir]",
+		Short: "writes the default templates to a directory for customization",
+		Long: `Writes the default templates to the given directory, or to the user template directory if none is given.
+Templates in the user template directory, or in a directory given with --template-dir, are used instead of the defaults.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var dir string
+			var err error
+			if len(args) == 1 {
+				dir = args[0]
+			} else if dir, err = templates.UserDir(); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if err = templates.Export(dir, overwriteTemplates); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(exportCmd)
+	exportCmd.Flags().BoolVar(&overwriteTemplates, "force", false, "Overwrite existing templates")
+}
@@ -20,13 +20,22 @@ var updateCmd = &cobra.Command{
 	Short: "updates pending releases",
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
-		projects := []string{project, "OCPBUGS"}
-		kRelease, err := konflux.NewRelease(namespace, releaseplan, version, projects, tailCommit)
+		projects := product.Projects()
+		opts, err := releaseOptions(tailCommit)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(1)
 		}
-		err = release.UpdateRelease(issue, project, version, true, time.Now(), kRelease.Release)
+		kRelease, err := konflux.NewRelease(namespace, releaseplan, version, projects, opts)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		if err = kRelease.CheckTests(force); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		err = release.UpdateRelease(issue, product, version, true, time.Now(), kRelease.Release)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(1)
@@ -38,11 +47,9 @@ func init() {
 	releaseCmd.AddCommand(updateCmd)
 	updateCmd.Flags().StringVar(&issue, "issue", "", "ticket to update")
 	updateCmd.MarkFlagRequired("issue")
-	updateCmd.Flags().StringVar(&releaseplan, "releaseplan", "", "Konflux releaseplan")
-	updateCmd.MarkFlagRequired("releaseplan")
+	updateCmd.Flags().StringVar(&releaseplan, "releaseplan", "", "Konflux releaseplan, defaults to the product's releasePlan")
 	updateCmd.Flags().StringVar(&version, "version", "", "Semver of the release")
 	updateCmd.MarkFlagRequired("version")
 	updateCmd.Flags().StringVar(&tailCommit, "tail", "", "tail commit of the release")
-	updateCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace")
-	updateCmd.MarkFlagRequired("namespace")
+	updateCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
 }
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/pkg/jira"
+	"github.com/sebsoto/gojira/pkg/konflux"
+	"github.com/sebsoto/gojira/pkg/release"
+)
+
+var (
+	description    string
+	showArchived   bool
+	releasedBefore string
+	nextVersion    string
+	konfluxRelease string
+	// versionCmd represents the version command
+	versionCmd = &cobra.Command{
+		Use:   "version",
+		Short: "manage Jira project versions",
+		Long:  `Manage the versions of the product's Jira project, which release issues are targeted at and fixed in`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			setFlagDefault(cmd, "project", product.JiraProject)
+			setFlagDefault(cmd, "namespace", product.Konflux.Namespace)
+		},
+	}
+	// versionCreateCmd represents the version create command
+	versionCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Creates the Jira version of a release",
+		Long: `Creates the Jira version tracking the given release, named by the product's versionName. The version starts at the
+engineering code freeze of the release's schedule, and is released on the GA date.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			parsedDate, err := time.Parse(time.DateOnly, date)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "given date has the wrong format\n")
+				os.Exit(1)
+			}
+			versionProduct := *product
+			versionProduct.JiraProject = project
+			created, err := release.CreateProjectVersion(&versionProduct, version, !majorRelease, parsedDate, description)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			fmt.Printf("Created version %s in %s, from %s to %s\n", created.Name, project, created.StartDate,
+				created.ReleaseDate)
+		},
+	}
+	// versionListCmd represents the version list command
+	versionListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "Lists the Jira versions of the project",
+		Long:  `Lists the versions of the project in the project's order. Archived versions are only listed with --all.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			versions, err := jira.GetVersions(project)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+			fmt.Fprintln(w, "Version\tStart\tRelease\tReleased\tArchived")
+			fmt.Fprintln(w, "___\t___\t___\t___\t___")
+			for _, v := range versions {
+				if v.Archived && !showArchived {
+					continue
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%t\n", v.Name, v.StartDate, v.ReleaseDate, v.Released, v.Archived)
+			}
+			w.Flush()
+		},
+	}
+	// versionArchiveCmd represents the version archive command
+	versionArchiveCmd = &cobra.Command{
+		Use:   "archive [name...]",
+		Short: "Archives old Jira versions",
+		Long: `Archives the named versions of the project, and with --released-before, every released version which was
+released before the given date.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			names := args
+			if releasedBefore != "" {
+				day, err := time.Parse(time.DateOnly, releasedBefore)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "given date has the wrong format\n")
+					os.Exit(1)
+				}
+				versions, err := jira.GetVersions(project)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+				for _, v := range release.VersionsReleasedBefore(versions, day) {
+					names = append(names, v.Name)
+				}
+			}
+			if len(names) == 0 {
+				fmt.Fprintln(os.Stderr, "no versions to archive, give version names or --released-before")
+				os.Exit(1)
+			}
+			if err := release.ArchiveVersions(project, names); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+	// versionReleaseCmd represents the version release command
+	versionReleaseCmd = &cobra.Command{
+		Use:   "release",
+		Short: "Marks the Jira version of a release as released",
+		Long: `Marks the Jira version of the given release as released, after moving its unresolved issues to the next
+unreleased version of the project, or the version given by --next. If a Konflux Release is given, the version is only
+released once the Konflux Release has succeeded.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			name, err := config.Render(product.VersionName, version)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			if konfluxRelease != "" {
+				rel, err := konflux.GetRelease(context.Background(), namespace, konfluxRelease)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err.Error())
+					os.Exit(1)
+				}
+				if result := rel.Result(); result != konflux.ResultSucceeded {
+					fmt.Fprintf(os.Stderr, "Release %s has not been released: %s\n", rel.Name, result)
+					os.Exit(1)
+				}
+			}
+			day := time.Now()
+			if date != "" {
+				if day, err = time.Parse(time.DateOnly, date); err != nil {
+					fmt.Fprintf(os.Stderr, "given date has the wrong format\n")
+					os.Exit(1)
+				}
+			}
+			if err = release.ReleaseProjectVersion(project, name, nextVersion, day); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.PersistentFlags().StringVar(&project, "project", "", "JIRA project, defaults to the product's project")
+
+	versionCmd.AddCommand(versionCreateCmd)
+	versionCreateCmd.Flags().StringVar(&version, "version", "", "Semver of the release")
+	versionCreateCmd.MarkFlagRequired("version")
+	versionCreateCmd.Flags().StringVar(&date, "date", "", "Planned GA date of the release")
+	versionCreateCmd.MarkFlagRequired("date")
+	versionCreateCmd.Flags().BoolVar(&majorRelease, "major", false, "Indicate this is a major release")
+	versionCreateCmd.Flags().StringVar(&description, "description", "", "Description of the version")
+
+	versionCmd.AddCommand(versionListCmd)
+	versionListCmd.Flags().BoolVar(&showArchived, "all", false, "Include archived versions")
+
+	versionCmd.AddCommand(versionArchiveCmd)
+	versionArchiveCmd.Flags().StringVar(&releasedBefore, "released-before", "",
+		"Archive every version released before this date")
+
+	versionCmd.AddCommand(versionReleaseCmd)
+	versionReleaseCmd.Flags().StringVar(&version, "version", "", "Semver of the release")
+	versionReleaseCmd.MarkFlagRequired("version")
+	versionReleaseCmd.Flags().StringVar(&date, "date", "", "Release date, defaults to today")
+	versionReleaseCmd.Flags().StringVar(&nextVersion, "next", "", "Version unresolved issues are moved to")
+	versionReleaseCmd.Flags().StringVar(&konfluxRelease, "release", "",
+		"Konflux Release which must have succeeded before the version is released")
+	versionReleaseCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
+}
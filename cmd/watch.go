@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sebsoto/gojira/pkg/konflux"
+)
+
+var (
+	pollInterval time.Duration
+	watchTimeout time.Duration
+	// watchCmd represents the watch command
+	watchCmd = &cobra.Command{
+		Use:   "watch <name>",
+		Short: "Follows a Konflux Release until it finishes",
+		Long: `Polls the given Konflux Release, printing changes to its status conditions and PipelineRuns until it finishes.
+Exits with 0 if the Release succeeded, 1 if it failed, and 2 if the timeout was reached.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := context.Background()
+			if watchTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, watchTimeout)
+				defer cancel()
+			}
+			_, err := konflux.WatchRelease(ctx, namespace, args[0], pollInterval, os.Stdout)
+			if errors.Is(err, context.DeadlineExceeded) {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(2)
+			} else if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				os.Exit(1)
+			}
+			fmt.Printf("Release %s succeeded\n", args[0])
+		},
+	}
+)
+
+func init() {
+	releaseCmd.AddCommand(watchCmd)
+	watchCmd.Flags().StringVar(&namespace, "namespace", "", "Konflux namespace, defaults to the product's namespace")
+	watchCmd.Flags().DurationVar(&pollInterval, "interval", 30*time.Second, "How often the Release is checked")
+	watchCmd.Flags().DurationVar(&watchTimeout, "timeout", 0, "How long to wait for the Release to finish, 0 waits forever")
+}
@@ -0,0 +1,378 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/sebsoto/gojira/pkg/semver"
+)
+
+// FileName is the name of the configuration file looked for in the home directory and the current directory
+const FileName = ".gojira.yaml"
+
+// Config describes the products gojira manages releases for
+type Config struct {
+	// DefaultProduct is the product used when none is specified
+	DefaultProduct string `json:"defaultProduct,omitempty"`
+	// Products maps a product name to its configuration
+	Products map[string]*Product `json:"products,omitempty"`
+	// Jira configures the Jira instance used
+	Jira Jira `json:"jira,omitempty"`
+	// Git configures access to the component repositories
+	Git Git `json:"git,omitempty"`
+}
+
+type Jira struct {
+	// URL is the base URL of the Jira instance
+	URL string `json:"url,omitempty"`
+}
+
+type Git struct {
+	// Providers maps self-hosted git hosts to the kind of provider serving them, e.g. gitlab.example.com: gitlab
+	Providers map[string]string `json:"providers,omitempty"`
+}
+
+// Product describes how releases of a single product are tracked in Jira and released through Konflux. String fields
+// described as templates are rendered with the release's Version, Major, Minor and Patch.
+type Product struct {
+	// JiraProject is the project release issues are created in, e.g. WINC
+	JiraProject string `json:"jiraProject"`
+	// TicketProjects are additional projects whose tickets are included in release notes, e.g. OCPBUGS
+	TicketProjects []string `json:"ticketProjects,omitempty"`
+	// DisplayName is the full name of the product
+	DisplayName string `json:"displayName,omitempty"`
+	// EpicSummary is a template for the summary of the release epic
+	EpicSummary string `json:"epicSummary,omitempty"`
+	// EpicName is a template for the name of the release epic
+	EpicName string `json:"epicName,omitempty"`
+	// TaskSummary is a template for the summary of the release task
+	TaskSummary string `json:"taskSummary,omitempty"`
+	// VersionName is a template for the name of the Jira version tracking the release, e.g. "WMCO {{ .Version }}"
+	VersionName string `json:"versionName,omitempty"`
+	// Labels are added to the release epic, and used to find it
+	Labels []string `json:"labels,omitempty"`
+	// TaskLabels are added to the release task
+	TaskLabels []string `json:"taskLabels,omitempty"`
+	// SecurityLevel restricts visibility of the release epic
+	SecurityLevel string `json:"securityLevel,omitempty"`
+	// Priority of the release issues
+	Priority string `json:"priority,omitempty"`
+	// Konflux describes where the product is built and released
+	Konflux Konflux `json:"konflux,omitempty"`
+	// Branches describes how release branches are named
+	Branches Branches `json:"branches,omitempty"`
+	// Schedule describes how release milestones are derived from the GA date
+	Schedule Schedule `json:"schedule,omitempty"`
+	// ReleaseNotes describes the product in the release notes of Konflux Releases
+	ReleaseNotes ReleaseNotes `json:"releaseNotes,omitempty"`
+	// Closing describes how Jira issues are transitioned once a release ships
+	Closing Closing `json:"closing,omitempty"`
+}
+
+type Konflux struct {
+	// Namespace is the tenant namespace the application is in
+	Namespace string `json:"namespace,omitempty"`
+	// Application is the name of the Konflux application the product is built as
+	Application string `json:"application,omitempty"`
+	// ReleasePlan is a template for the name of the ReleasePlan used for production releases
+	ReleasePlan string `json:"releasePlan,omitempty"`
+	// StageReleasePlan is a template for the name of the ReleasePlan used for stage releases
+	StageReleasePlan string `json:"stageReleasePlan,omitempty"`
+	// ReleaseURL is a template for a link to a created Release, rendered with its Name, Namespace and Application.
+	// If unset, the Release's URL in the cluster API is used.
+	ReleaseURL string `json:"releaseURL,omitempty"`
+}
+
+type ReleaseNotes struct {
+	// ProductID are the errata tool IDs of the product
+	ProductID []int `json:"productID,omitempty"`
+	// ProductName is the name of the product in errata, defaults to the display name
+	ProductName string `json:"productName,omitempty"`
+	// ProductVersion is a template for the product version in errata, e.g. "{{ .Major }}.{{ .Minor }}"
+	ProductVersion string `json:"productVersion,omitempty"`
+	// ProductStream is a template for the product stream in errata
+	ProductStream string `json:"productStream,omitempty"`
+	// CPE is the Common Platform Enumeration identifier of the product
+	CPE string `json:"cpe,omitempty"`
+	// References are links added to the release notes of every release
+	References []string `json:"references,omitempty"`
+	// CVEFields are the Jira custom fields CVE IDs are read from, in addition to issue labels and summaries, e.g.
+	// customfield_12324749
+	CVEFields []string `json:"cveFields,omitempty"`
+}
+
+type Closing struct {
+	// Status is the status the release task and epic are moved to, defaults to Closed
+	Status string `json:"status,omitempty"`
+	// Resolution is set on issues moved to Status, defaults to Done
+	Resolution string `json:"resolution,omitempty"`
+	// Issues maps the status of an issue included in the release to the status it is moved to. Issues in other
+	// statuses are left as they are. Defaults to moving ON_QA issues to Release Pending and Verified issues to Closed.
+	Issues map[string]string `json:"issues,omitempty"`
+}
+
+// DefaultClosing returns the closing settings used when a product does not configure them
+func DefaultClosing() Closing {
+	return Closing{
+		Status:     "Closed",
+		Resolution: "Done",
+		Issues: map[string]string{
+			"ON_QA":    "Release Pending",
+			"Verified": "Closed",
+		},
+	}
+}
+
+type Branches struct {
+	// Pattern matches release branches, capturing the changing part of the name in a group named version
+	Pattern string `json:"pattern,omitempty"`
+	// Rule is how the previous release branch is derived, see git.NewBranchStrategy
+	Rule string `json:"rule,omitempty"`
+	// Previous maps release branches to the branch of the previous release
+	Previous map[string]string `json:"previous,omitempty"`
+}
+
+type Schedule struct {
+	// Major holds the milestone offsets of major releases. If unset, the defaults are used.
+	Major *ScheduleOffsets `json:"major,omitempty"`
+	// ZStream holds the milestone offsets of z-stream releases. If unset, the defaults are used.
+	ZStream *ScheduleOffsets `json:"zstream,omitempty"`
+	// Holidays is the path to a file listing non-working days, one YYYY-MM-DD date per line
+	Holidays string `json:"holidays,omitempty"`
+}
+
+// ScheduleOffsets are the number of days between release milestones. Each milestone is moved to the closest earlier
+// working day, except for the start of QE testing which is moved to the closest later working day.
+type ScheduleOffsets struct {
+	// QEEnd is the number of days before GA that QE testing ends
+	QEEnd int `json:"qeEnd"`
+	// QEPeriod is the number of days before the end of QE testing that the final build is handed over to QE
+	QEPeriod int `json:"qePeriod"`
+	// QEStartDelay is the number of days after the handover that QE testing starts
+	QEStartDelay int `json:"qeStartDelay"`
+	// EngFreeze is the number of days before the handover that engineering code freeze begins
+	EngFreeze int `json:"engFreeze"`
+}
+
+// DefaultProduct returns the configuration used when no configuration file exists, describing the Windows Machine
+// Config Operator
+func DefaultProduct() *Product {
+	return &Product{
+		JiraProject:    "WINC",
+		TicketProjects: []string{"OCPBUGS"},
+		DisplayName:    "Windows Machine Config Operator",
+		EpicSummary:    "Windows Machine Config Operator {{ .Version }} Release",
+		EpicName:       "WMCO {{ .Version }} Release",
+		TaskSummary:    "Red Hat OpenShift for Windows Containers {{ .Version }} Release",
+		VersionName:    "WMCO {{ .Version }}",
+		Labels:         []string{"OperatorProductization"},
+		TaskLabels:     []string{"docs", "qe", "release"},
+		SecurityLevel:  "Red Hat Employee",
+		Priority:       "Major",
+		Closing:        DefaultClosing(),
+	}
+}
+
+// Load reads the configuration from path. If path is empty, ~/.gojira.yaml is read, followed by .gojira.yaml in the
+// current directory, whose products take precedence. Missing files are ignored.
+func Load(path string) (*Config, error) {
+	if path != "" {
+		return readFile(path)
+	}
+	var paths []string
+	if homedir, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(homedir, FileName))
+	}
+	if wd, err := os.Getwd(); err == nil {
+		paths = append(paths, filepath.Join(wd, FileName))
+	}
+	c := &Config{}
+	for _, p := range slices.Compact(paths) {
+		fileConfig, err := readFile(p)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		c.merge(fileConfig)
+	}
+	return c, nil
+}
+
+func readFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Config
+	if err = yaml.UnmarshalStrict(data, &c); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// merge overlays other onto c
+func (c *Config) merge(other *Config) {
+	if other.DefaultProduct != "" {
+		c.DefaultProduct = other.DefaultProduct
+	}
+	if c.Products == nil {
+		c.Products = make(map[string]*Product)
+	}
+	for name, product := range other.Products {
+		c.Products[name] = product
+	}
+	if other.Jira.URL != "" {
+		c.Jira.URL = other.Jira.URL
+	}
+	for host, kind := range other.Git.Providers {
+		if c.Git.Providers == nil {
+			c.Git.Providers = make(map[string]string)
+		}
+		c.Git.Providers[host] = kind
+	}
+}
+
+// Product returns the product with the given name. If name is empty, the default product is returned, which is either
+// the configured default, the only configured product, or DefaultProduct if none are configured.
+func (c *Config) Product(name string) (*Product, error) {
+	if name == "" {
+		name = c.DefaultProduct
+	}
+	if name == "" {
+		switch len(c.Products) {
+		case 0:
+			return DefaultProduct(), nil
+		case 1:
+			for _, product := range c.Products {
+				return product.withDefaults(), nil
+			}
+		default:
+			return nil, fmt.Errorf("multiple products configured, a product must be specified")
+		}
+	}
+	product, ok := c.Products[name]
+	if !ok {
+		return nil, fmt.Errorf("product %s not found in configuration", name)
+	}
+	return product.withDefaults(), nil
+}
+
+// withDefaults returns a copy of the product with unset naming fields derived from the display name
+func (p *Product) withDefaults() *Product {
+	product := *p
+	if product.DisplayName == "" {
+		product.DisplayName = product.JiraProject
+	}
+	if product.EpicSummary == "" {
+		product.EpicSummary = product.DisplayName + " {{ .Version }} Release"
+	}
+	if product.EpicName == "" {
+		product.EpicName = product.EpicSummary
+	}
+	if product.TaskSummary == "" {
+		product.TaskSummary = product.EpicSummary
+	}
+	if product.VersionName == "" {
+		product.VersionName = product.DisplayName + " {{ .Version }}"
+	}
+	if product.Priority == "" {
+		product.Priority = "Major"
+	}
+	closing := DefaultClosing()
+	if product.Closing.Status == "" {
+		product.Closing.Status = closing.Status
+	}
+	if product.Closing.Resolution == "" {
+		product.Closing.Resolution = closing.Resolution
+	}
+	if product.Closing.Issues == nil {
+		product.Closing.Issues = closing.Issues
+	}
+	return &product
+}
+
+// Projects returns the Jira projects whose tickets are included in a release
+func (p *Product) Projects() []string {
+	return append([]string{p.JiraProject}, p.TicketProjects...)
+}
+
+const (
+	EnvironmentProd  = "prod"
+	EnvironmentStage = "stage"
+)
+
+// ReleasePlanEnvironment returns whether the named ReleasePlan is the product's production or stage plan of any
+// version, by matching it against the ReleasePlan templates. An empty string is returned if neither matches.
+func (p *Product) ReleasePlanEnvironment(name string) string {
+	if matchesTemplate(p.Konflux.StageReleasePlan, name) {
+		return EnvironmentStage
+	}
+	if matchesTemplate(p.Konflux.ReleasePlan, name) {
+		return EnvironmentProd
+	}
+	return ""
+}
+
+// templateAction matches the actions of a name template
+var templateAction = regexp.MustCompile(`{{.*?}}`)
+
+// matchesTemplate returns true if name could have been rendered from the given name template
+func matchesTemplate(nameTemplate, name string) bool {
+	if nameTemplate == "" {
+		return false
+	}
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range templateAction.FindAllStringIndex(nameTemplate, -1) {
+		pattern.WriteString(regexp.QuoteMeta(nameTemplate[last:loc[0]]))
+		pattern.WriteString(".+")
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(nameTemplate[last:]))
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String()).MatchString(name)
+}
+
+// versionData is passed to name templates
+type versionData struct {
+	Version string
+	Major   int
+	Minor   int
+	Patch   int
+}
+
+// Render executes the given name template for the given version, e.g. Render(p.VersionName, "10.19.0")
+func Render(nameTemplate, version string) (string, error) {
+	if nameTemplate == "" {
+		return "", nil
+	}
+	v, err := semver.New(version)
+	if err != nil {
+		return "", err
+	}
+	t, err := template.New("name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", err
+	}
+	out := new(bytes.Buffer)
+	err = t.Execute(out, versionData{
+		Version: strings.TrimPrefix(version, "v"),
+		Major:   v.Major,
+		Minor:   v.Minor,
+		Patch:   v.Patch,
+	})
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadProduct(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	err := os.WriteFile(path, []byte(`
+defaultProduct: wmco
+products:
+  wmco:
+    jiraProject: WINC
+    ticketProjects: [OCPBUGS]
+    displayName: Windows Machine Config Operator
+    versionName: "WMCO {{ .Version }}"
+    konflux:
+      namespace: windows-machine-conf-tenant
+      releasePlan: "windows-machine-config-operator-{{ .Major }}-{{ .Minor }}-prod"
+  other:
+    jiraProject: OTHER
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := c.Product("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projects := p.Projects(); len(projects) != 2 || projects[0] != "WINC" || projects[1] != "OCPBUGS" {
+		t.Errorf("unexpected projects %v", projects)
+	}
+	releasePlan, err := Render(p.Konflux.ReleasePlan, "v10.19.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if releasePlan != "windows-machine-config-operator-10-19-prod" {
+		t.Errorf("unexpected release plan %s", releasePlan)
+	}
+	epicSummary, err := Render(p.EpicSummary, "v10.19.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if epicSummary != "Windows Machine Config Operator 10.19.1 Release" {
+		t.Errorf("unexpected epic summary %s", epicSummary)
+	}
+
+	other, err := c.Product("other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.DisplayName != "OTHER" || other.Priority != "Major" {
+		t.Errorf("defaults not applied: %+v", other)
+	}
+	if _, err = c.Product("missing"); err == nil {
+		t.Error("expected error for missing product")
+	}
+}
+
+func TestLoadRejectsUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("products:\n  wmco:\n    jiraProjct: WINC\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for misspelled field")
+	}
+}
+
+func TestReleasePlanEnvironment(t *testing.T) {
+	p := &Product{Konflux: Konflux{
+		ReleasePlan:      "wmco-{{ .Major }}-{{ .Minor }}-prod",
+		StageReleasePlan: "wmco-{{ .Major }}-{{ .Minor }}-stage",
+	}}
+	testCases := map[string]string{
+		"wmco-10-19-prod":  EnvironmentProd,
+		"wmco-10-19-stage": EnvironmentStage,
+		"wmco-10-19-dev":   "",
+		"other-10-19-prod": "",
+	}
+	for name, expected := range testCases {
+		if actual := p.ReleasePlanEnvironment(name); actual != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, actual)
+		}
+	}
+}
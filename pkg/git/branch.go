@@ -0,0 +1,93 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PreviousRuleDecrement derives the previous branch by decrementing the last number of the captured version, e.g.
+	// release-4.16 -> release-4.15 or release-v10.19 -> release-v10.18
+	PreviousRuleDecrement = "decrement"
+	// PreviousRuleNone never derives a previous branch, only explicit mappings are used
+	PreviousRuleNone = "none"
+)
+
+// DefaultBranchPattern matches the release-4.N branches used by OpenShift
+const DefaultBranchPattern = `^release-4\.(?P<version>\d+)$`
+
+// BranchStrategy describes how release branches are named, so that the branch of the previous release can be derived
+// from the branch a snapshot was built from
+type BranchStrategy struct {
+	// pattern matches release branches, capturing the part of the name which changes between releases in the group
+	// named "version"
+	pattern *regexp.Regexp
+	// rule is how the previous branch is derived from the captured version
+	rule string
+	// previous maps branches to their previous branch, taking precedence over the rule
+	previous map[string]string
+}
+
+// NewBranchStrategy returns a strategy for release branches matching pattern, which must contain a capture group named
+// "version". Branches found in previous are mapped to the given branch, all others are derived using rule.
+func NewBranchStrategy(pattern, rule string, previous map[string]string) (*BranchStrategy, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if re.SubexpIndex("version") == -1 {
+		return nil, fmt.Errorf("branch pattern %s has no capture group named version", pattern)
+	}
+	switch rule {
+	case "":
+		rule = PreviousRuleDecrement
+	case PreviousRuleDecrement, PreviousRuleNone:
+	default:
+		return nil, fmt.Errorf("unknown previous branch rule: %s", rule)
+	}
+	return &BranchStrategy{pattern: re, rule: rule, previous: previous}, nil
+}
+
+// DefaultBranchStrategy returns the strategy for release-4.N branches
+func DefaultBranchStrategy() *BranchStrategy {
+	s, _ := NewBranchStrategy(DefaultBranchPattern, PreviousRuleDecrement, nil)
+	return s
+}
+
+// PreviousBranch returns the release branch preceding the given one. If the branch is not a release branch, or the
+// previous branch cannot be derived, false is returned.
+func (s *BranchStrategy) PreviousBranch(branch string) (string, bool, error) {
+	if prev, ok := s.previous[branch]; ok {
+		return prev, true, nil
+	}
+	match := s.pattern.FindStringSubmatchIndex(branch)
+	if match == nil || s.rule == PreviousRuleNone {
+		return "", false, nil
+	}
+	index := s.pattern.SubexpIndex("version")
+	start, end := match[2*index], match[2*index+1]
+	if start == -1 {
+		return "", false, nil
+	}
+	prevVersion, err := decrementVersion(branch[start:end])
+	if err != nil {
+		return "", false, fmt.Errorf("unable to derive previous branch of %s: %w", branch, err)
+	}
+	return branch[:start] + prevVersion + branch[end:], true, nil
+}
+
+// decrementVersion decrements the last number of a dot separated version, e.g. 10.19 -> 10.18
+func decrementVersion(version string) (string, error) {
+	parts := strings.Split(version, ".")
+	last, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return "", err
+	}
+	if last == 0 {
+		return "", fmt.Errorf("version %s has no previous version", version)
+	}
+	parts[len(parts)-1] = strconv.Itoa(last - 1)
+	return strings.Join(parts, "."), nil
+}
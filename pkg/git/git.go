@@ -3,27 +3,45 @@ package git
 import (
 	"context"
 	"fmt"
+	"io/fs"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v72/github"
 
 	"github.com/sebsoto/gojira/pkg/semver"
 )
 
-type FilterFunction func(*github.Commit) bool
+// FilterFunction reports whether a commit should be included in a commit listing
+type FilterFunction func(Commit) bool
 
 type Commit struct {
 	Message string
 	SHA     string
+	// Parents holds the SHAs of the commit's parents
+	Parents []string
+	// Date is when the commit was committed
+	Date time.Time
 }
 
 type Repo interface {
 	GetTags() ([]Tag, error)
 	ListCommits(string, string, FilterFunction) ([]Commit, error)
 	MergeBase(string, string) (string, error)
+	// ReadFile returns the contents of the file at the given path at the given ref. If the file does not exist, an
+	// error wrapping fs.ErrNotExist is returned.
+	ReadFile(ref, path string) ([]byte, error)
+}
+
+// fileNotFound returns an error wrapping fs.ErrNotExist for a file missing at the given ref
+func fileNotFound(ref, path string) error {
+	return fmt.Errorf("%s at %s: %w", path, ref, fs.ErrNotExist)
 }
 
 type Tag struct {
@@ -37,19 +55,108 @@ type GithubRepo struct {
 	client *github.Client
 }
 
+const (
+	// BackendAPI accesses repositories through the git provider's REST API
+	BackendAPI = "api"
+	// BackendLocal accesses repositories through a local clone
+	BackendLocal = "local"
+)
+
+// RepoOptions control how NewRepo accesses repositories
+type RepoOptions struct {
+	// Backend is either BackendAPI or BackendLocal
+	Backend string
+	// CacheDir is the directory clones are kept in by the local backend. If empty, a directory in the user's cache
+	// directory is used.
+	CacheDir string
+	// LocalPath is a local checkout or bare clone to use instead of the repository at the given URL
+	LocalPath string
+}
+
+// DefaultRepoOptions are the options used by NewRepo
+var DefaultRepoOptions = RepoOptions{Backend: BackendAPI}
+
+// NewRepo returns a Repo for the given URL. Local paths and file:// URLs, as well as any URL when a local path or the
+// local backend is set in DefaultRepoOptions, are accessed through a local clone.
 func NewRepo(gitURL string) (Repo, error) {
 	u, err := url.Parse(gitURL)
 	if err != nil {
 		return nil, err
 	}
-	if u.Host != "github.com" {
+	if u.Scheme == "" || u.Scheme == "file" {
+		return NewLocalRepo(u.Path)
+	}
+	if DefaultRepoOptions.LocalPath != "" {
+		return NewLocalRepo(DefaultRepoOptions.LocalPath)
+	}
+	switch DefaultRepoOptions.Backend {
+	case BackendAPI, "":
+	case BackendLocal:
+		return NewCachedRepo(gitURL, DefaultRepoOptions.CacheDir)
+	default:
+		return nil, fmt.Errorf("unsupported git backend: %s", DefaultRepoOptions.Backend)
+	}
+	kind, ok := providerHosts[u.Host]
+	if !ok {
 		return nil, fmt.Errorf("unsupported git provider: %s", u.Host)
 	}
+	provider, ok := providers[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown git provider %s for host %s", kind, u.Host)
+	}
+	return provider(u)
+}
+
+const (
+	ProviderGithub = "github"
+	ProviderGitlab = "gitlab"
+)
+
+// ProviderFunc returns a Repo for the repository at the given URL
+type ProviderFunc func(u *url.URL) (Repo, error)
+
+// providers maps a provider kind to the function creating Repos for it
+var providers = map[string]ProviderFunc{
+	ProviderGithub: newGithubRepoFromURL,
+	ProviderGitlab: newGitlabRepoFromURL,
+}
+
+// providerHosts maps a git host to the kind of provider serving it
+var providerHosts = map[string]string{
+	"github.com":            ProviderGithub,
+	"gitlab.com":            ProviderGitlab,
+	"gitlab.cee.redhat.com": ProviderGitlab,
+}
+
+// RegisterProvider makes a provider kind available to NewRepo, replacing any existing provider of the same kind
+func RegisterProvider(kind string, provider ProviderFunc) {
+	providers[kind] = provider
+}
+
+// RegisterHost maps a host, such as a self-hosted Gitlab instance, to a provider kind
+func RegisterHost(host, kind string) error {
+	if _, ok := providers[kind]; !ok {
+		return fmt.Errorf("unknown git provider %s for host %s", kind, host)
+	}
+	providerHosts[host] = kind
+	return nil
+}
+
+func newGithubRepoFromURL(u *url.URL) (Repo, error) {
 	urlSplit := strings.Split(u.Path, "/")
 	if len(urlSplit) < 3 {
-		return nil, fmt.Errorf("unexpected URL path: %s", gitURL)
+		return nil, fmt.Errorf("unexpected URL path: %s", u)
+	}
+	repo := NewGithubRepo(urlSplit[1], strings.TrimSuffix(urlSplit[2], ".git"))
+	if u.Host != "github.com" {
+		// Github Enterprise serves its API under the instance's host
+		client, err := repo.client.WithEnterpriseURLs("https://"+u.Host+"/api/v3/", "https://"+u.Host+"/api/uploads/")
+		if err != nil {
+			return nil, err
+		}
+		repo.client = client
 	}
-	return NewGithubRepo(urlSplit[1], urlSplit[2]), nil
+	return repo, nil
 }
 
 func NewGithubRepo(owner string, name string) *GithubRepo {
@@ -68,17 +175,21 @@ func NewGithubRepo(owner string, name string) *GithubRepo {
 }
 
 func (r *GithubRepo) GetTags() ([]Tag, error) {
-	tags, _, err := r.client.Repositories.ListTags(context.Background(), r.owner, r.name, &github.ListOptions{
-		PerPage: 100,
-	})
-	if err != nil {
-		return nil, err
-	}
 	tagList := make([]Tag, 0)
-	for _, tag := range tags {
-		tagList = append(tagList, Tag{Name: tag.GetName(), Sha: tag.GetCommit().GetSHA()})
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		tags, resp, err := r.client.Repositories.ListTags(context.Background(), r.owner, r.name, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagList = append(tagList, Tag{Name: tag.GetName(), Sha: tag.GetCommit().GetSHA()})
+		}
+		if resp.NextPage == 0 {
+			return tagList, nil
+		}
+		opts.Page = resp.NextPage
 	}
-	return tagList, nil
 }
 
 func (r *GithubRepo) ListCommits(startSHA, endSHA string, filter FilterFunction) ([]Commit, error) {
@@ -97,11 +208,8 @@ func (r *GithubRepo) ListCommits(startSHA, endSHA string, filter FilterFunction)
 		if commit.GetSHA() == endSHA {
 			return commitList, nil
 		}
-		if !filter(commit.GetCommit()) {
-			commitList = append(commitList, Commit{
-				Message: commit.GetCommit().GetMessage(),
-				SHA:     commit.GetSHA(),
-			})
+		if c := newGithubCommit(commit); filter(c) {
+			commitList = append(commitList, c)
 		}
 	}
 	for nextPage := resp.NextPage; nextPage != resp.LastPage; nextPage = resp.NextPage {
@@ -120,17 +228,50 @@ func (r *GithubRepo) ListCommits(startSHA, endSHA string, filter FilterFunction)
 			if commit.GetSHA() == endSHA {
 				return commitList, nil
 			}
-			if !filter(commit.GetCommit()) {
-				commitList = append(commitList, Commit{
-					Message: commit.GetCommit().GetMessage(),
-					SHA:     commit.GetSHA(),
-				})
+			if c := newGithubCommit(commit); filter(c) {
+				commitList = append(commitList, c)
 			}
 		}
 	}
 	return commitList, nil
 }
 
+func newGithubCommit(commit *github.RepositoryCommit) Commit {
+	var parents []string
+	for _, parent := range commit.Parents {
+		parents = append(parents, parent.GetSHA())
+	}
+	return Commit{
+		Message: commit.GetCommit().GetMessage(),
+		SHA:     commit.GetSHA(),
+		Parents: parents,
+		Date:    commit.GetCommit().GetCommitter().GetDate().Time,
+	}
+}
+
+func (r *GithubRepo) ReadFile(ref, path string) ([]byte, error) {
+	file, _, resp, err := r.client.Repositories.GetContents(context.Background(), r.owner, r.name, path,
+		&github.RepositoryContentGetOptions{Ref: ref})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fileNotFound(ref, path)
+	} else if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%s at %s is a directory", path, ref)
+	}
+	// Files larger than 1MB are not returned with their metadata, and must be read as a blob
+	if file.GetEncoding() == "none" {
+		contents, _, err := r.client.Git.GetBlobRaw(context.Background(), r.owner, r.name, file.GetSHA())
+		return contents, err
+	}
+	contents, err := file.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(contents), nil
+}
+
 func (r *GithubRepo) MergeBase(sha1, sha2 string) (string, error) {
 	comparison, _, err := r.client.Repositories.CompareCommits(context.Background(), r.owner, r.name, sha1, sha2, nil)
 	if err != nil {
@@ -139,23 +280,33 @@ func (r *GithubRepo) MergeBase(sha1, sha2 string) (string, error) {
 	return comparison.MergeBaseCommit.GetSHA(), nil
 }
 
-// FindPreviousTag returns the commit of the previous tag
+// NoPreviousReleaseError is returned when there is no release tag with a lower version than the given one
+type NoPreviousReleaseError struct {
+	Version semver.Semver
+}
+
+func (e *NoPreviousReleaseError) Error() string {
+	return fmt.Sprintf("no release found previous to v%s", e.Version)
+}
+
+// FindPreviousTag returns the commit of the previous release tag, ignoring pre-releases
 func FindPreviousTag(repo Repo, currentTag semver.Semver) (string, error) {
-	tags, err := repo.GetTags()
+	tag, err := PreviousTag(repo, currentTag, false)
 	if err != nil {
 		return "", err
 	}
-	if currentTag.Patch != 0 {
-		prevTagName := fmt.Sprintf("v%d.%d.%d", currentTag.Major, currentTag.Minor, currentTag.Patch-1)
-		for _, tag := range tags {
-			if tag.Name == prevTagName {
-				fmt.Printf("Tag %s found\nCommit %s\n", tag.Name, tag.Sha)
-				return tag.Sha, nil
-			}
-		}
-		return "", fmt.Errorf("no previous tag found")
+	return tag.Sha, nil
+}
+
+// PreviousTag returns the tag with the highest version strictly lower than currentTag. Tags which are not semvers are
+// ignored, as are pre-releases unless includePrereleases is set. If there is no such tag a NoPreviousReleaseError is
+// returned.
+func PreviousTag(repo Repo, currentTag semver.Semver, includePrereleases bool) (*Tag, error) {
+	tags, err := repo.GetTags()
+	if err != nil {
+		return nil, err
 	}
-	var prevTag Tag
+	var prevTag *Tag
 	var prevTagSemver semver.Semver
 	for _, tag := range tags {
 		tagSemver, err := semver.New(tag.Name)
@@ -163,16 +314,83 @@ func FindPreviousTag(repo Repo, currentTag semver.Semver) (string, error) {
 			fmt.Fprintf(os.Stderr, "WARNING: unable to parse semver %s: %s\n", tag.Name, err)
 			continue
 		}
-		if tagSemver.Major == currentTag.Major && tagSemver.Minor == currentTag.Minor-1 && tagSemver.Patch > prevTagSemver.Patch {
+		if tagSemver.IsPrerelease() && !includePrereleases {
+			continue
+		}
+		if !tagSemver.Less(currentTag) {
+			continue
+		}
+		if prevTag == nil || prevTagSemver.Less(*tagSemver) {
+			prevTag = &tag
 			prevTagSemver = *tagSemver
-			prevTag = tag
-			break
 		}
 	}
-	return prevTag.Sha, nil
+	if prevTag == nil {
+		return nil, &NoPreviousReleaseError{Version: currentTag}
+	}
+	fmt.Fprintf(os.Stderr, "Tag %s found\nCommit %s\n", prevTag.Name, prevTag.Sha)
+	return prevTag, nil
+}
+
+// FirstTagContaining returns the first release tag, in version order, whose changes since the preceding release tag
+// include a commit matched by filter, along with the newest such commit. Pre-release tags and tags which are not semvers
+// are ignored. If the preceding tag is not an ancestor, such as a tag on another release branch, the changes are listed
+// from the merge base of both tags. The whole history of the oldest release tag is searched. If no tag contains a
+// matching commit, nil is returned.
+func FirstTagContaining(repo Repo, filter FilterFunction) (*Tag, *Commit, error) {
+	tags, err := repo.GetTags()
+	if err != nil {
+		return nil, nil, err
+	}
+	type versionedTag struct {
+		tag     Tag
+		version semver.Semver
+	}
+	var releaseTags []versionedTag
+	for _, tag := range tags {
+		tagSemver, err := semver.New(tag.Name)
+		if err != nil || tagSemver.IsPrerelease() {
+			continue
+		}
+		releaseTags = append(releaseTags, versionedTag{tag: tag, version: *tagSemver})
+	}
+	slices.SortStableFunc(releaseTags, func(a, b versionedTag) int {
+		return a.version.Compare(b.version)
+	})
+	for i, releaseTag := range releaseTags {
+		tag := releaseTag.tag
+		var base string
+		if i > 0 {
+			if base, err = repo.MergeBase(releaseTags[i-1].tag.Sha, tag.Sha); err != nil {
+				return nil, nil, err
+			}
+		}
+		commits, err := repo.ListCommits(tag.Sha, base, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(commits) > 0 {
+			return &tag, &commits[0], nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// Mentions includes commits whose message mentions the given word, such as a Jira issue key
+func Mentions(word string) FilterFunction {
+	re := regexp.MustCompile(`\b` + regexp.QuoteMeta(word) + `\b`)
+	return func(commit Commit) bool {
+		return re.MatchString(commit.Message)
+	}
+}
+
+// AllCommits includes every commit
+func AllCommits(Commit) bool {
+	return true
 }
 
-func IsMerge(commit *github.Commit) bool {
+// IsMerge includes only merge commits
+func IsMerge(commit Commit) bool {
 	return len(commit.Parents) > 1
 }
 
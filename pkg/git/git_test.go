@@ -0,0 +1,115 @@
+package git
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sebsoto/gojira/pkg/semver"
+)
+
+// fakeRepo is a Repo with a fixed set of tags
+type fakeRepo struct {
+	tags []Tag
+}
+
+func (r *fakeRepo) GetTags() ([]Tag, error) {
+	return r.tags, nil
+}
+
+func (r *fakeRepo) ListCommits(string, string, FilterFunction) ([]Commit, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) MergeBase(string, string) (string, error) {
+	return "", nil
+}
+
+func (r *fakeRepo) ReadFile(ref, path string) ([]byte, error) {
+	return nil, fileNotFound(ref, path)
+}
+
+func TestPreviousTag(t *testing.T) {
+	repo := &fakeRepo{tags: []Tag{
+		{Name: "v10.18.0", Sha: "10.18.0"},
+		{Name: "v10.18.2", Sha: "10.18.2"},
+		{Name: "v10.18.1", Sha: "10.18.1"},
+		{Name: "v10.19.0-rc.1", Sha: "10.19.0-rc.1"},
+		{Name: "v10.19.0", Sha: "10.19.0"},
+		{Name: "v10.19.3", Sha: "10.19.3"},
+		{Name: "v10.19.1", Sha: "10.19.1"},
+		{Name: "latest", Sha: "latest"},
+	}}
+	testCases := []struct {
+		version            string
+		includePrereleases bool
+		expected           string
+	}{
+		{version: "v10.19.1", expected: "10.19.0"},
+		{version: "v10.19.0", expected: "10.18.2"},
+		{version: "v10.19.0", includePrereleases: true, expected: "10.19.0-rc.1"},
+		{version: "v10.18.3", expected: "10.18.2"},
+		{version: "v10.19.5", expected: "10.19.3"},
+		{version: "v11.0.0", expected: "10.19.3"},
+		{version: "v10.18.0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			version, err := semver.New(tc.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tag, err := PreviousTag(repo, *version, tc.includePrereleases)
+			if tc.expected == "" {
+				var noPreviousRelease *NoPreviousReleaseError
+				if !errors.As(err, &noPreviousRelease) {
+					t.Fatalf("expected NoPreviousReleaseError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tag.Sha != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, tag.Sha)
+			}
+		})
+	}
+}
+
+func TestPreviousBranch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pattern  string
+		rule     string
+		previous map[string]string
+		branch   string
+		expected string
+		found    bool
+	}{
+		{name: "openshift", pattern: DefaultBranchPattern, branch: "release-4.16", expected: "release-4.15", found: true},
+		{name: "not a release branch", pattern: DefaultBranchPattern, branch: "master"},
+		{name: "other major", pattern: `^release-1\.(?P<version>\d+)$`, branch: "release-1.3", expected: "release-1.2",
+			found: true},
+		{name: "full version", pattern: `^release-v(?P<version>\d+\.\d+)$`, branch: "release-v10.19",
+			expected: "release-v10.18", found: true},
+		{name: "explicit mapping", pattern: `^rhoso-(?P<version>.*)$`, rule: PreviousRuleNone,
+			previous: map[string]string{"rhoso-18.0-fr2": "rhoso-18.0-fr1"}, branch: "rhoso-18.0-fr2",
+			expected: "rhoso-18.0-fr1", found: true},
+		{name: "no rule", pattern: `^rhoso-(?P<version>.*)$`, rule: PreviousRuleNone, branch: "rhoso-18.0-fr3"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategy, err := NewBranchStrategy(tc.pattern, tc.rule, tc.previous)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prev, found, err := strategy.PreviousBranch(tc.branch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found != tc.found || prev != tc.expected {
+				t.Errorf("expected %q, %t, got %q, %t", tc.expected, tc.found, prev, found)
+			}
+		})
+	}
+}
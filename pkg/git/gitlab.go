@@ -0,0 +1,188 @@
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GitlabRepo is a Repo accessed through the REST API of a Gitlab instance
+type GitlabRepo struct {
+	// apiURL is the root of the instance's REST API, e.g. https://gitlab.com/api/v4
+	apiURL string
+	// project is the full path of the project, e.g. group/subgroup/name
+	project string
+	token   string
+	client  *http.Client
+}
+
+type gitlabTag struct {
+	Name   string       `json:"name"`
+	Commit gitlabCommit `json:"commit"`
+}
+
+type gitlabCommit struct {
+	ID        string   `json:"id"`
+	Message   string   `json:"message"`
+	ParentIDs []string `json:"parent_ids"`
+	// CommittedDate is when the commit was committed
+	CommittedDate time.Time `json:"committed_date"`
+}
+
+func newGitlabRepoFromURL(u *url.URL) (Repo, error) {
+	project := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if strings.Count(project, "/") < 1 {
+		return nil, fmt.Errorf("unexpected URL path: %s", u)
+	}
+	return NewGitlabRepo(u.Host, project), nil
+}
+
+// NewGitlabRepo returns a Repo for the project with the given path on the Gitlab instance at host
+func NewGitlabRepo(host, project string) *GitlabRepo {
+	token, err := getGitlabAPIToken(host)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: unable to access gitlab api token: %s\n", err)
+	}
+	return &GitlabRepo{
+		apiURL:  "https://" + host + "/api/v4",
+		project: project,
+		token:   token,
+		client:  http.DefaultClient,
+	}
+}
+
+func (r *GitlabRepo) GetTags() ([]Tag, error) {
+	tagList := make([]Tag, 0)
+	for page := "1"; page != ""; {
+		var tags []gitlabTag
+		nextPage, err := r.get("/repository/tags", url.Values{"page": []string{page}}, &tags)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagList = append(tagList, Tag{Name: tag.Name, Sha: tag.Commit.ID})
+		}
+		page = nextPage
+	}
+	return tagList, nil
+}
+
+func (r *GitlabRepo) ListCommits(startSHA, endSHA string, filter FilterFunction) ([]Commit, error) {
+	var commitList []Commit
+	for page := "1"; page != ""; {
+		var commits []gitlabCommit
+		nextPage, err := r.get("/repository/commits", url.Values{"ref_name": []string{startSHA}, "page": []string{page}},
+			&commits)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			if commit.ID == endSHA {
+				return commitList, nil
+			}
+			c := Commit{
+				Message: commit.Message,
+				SHA:     commit.ID,
+				Parents: commit.ParentIDs,
+				Date:    commit.CommittedDate,
+			}
+			if filter(c) {
+				commitList = append(commitList, c)
+			}
+		}
+		page = nextPage
+	}
+	return commitList, nil
+}
+
+func (r *GitlabRepo) MergeBase(sha1, sha2 string) (string, error) {
+	var commit gitlabCommit
+	_, err := r.get("/repository/merge_base", url.Values{"refs[]": []string{sha1, sha2}}, &commit)
+	if err != nil {
+		return "", err
+	}
+	return commit.ID, nil
+}
+
+// get makes a GET request against the given project endpoint, unmarshals the response into v, and returns the number
+// of the next page of results, which is empty on the last page
+func (r *GitlabRepo) get(endpoint string, queries url.Values, v any) (string, error) {
+	queries.Set("per_page", "100")
+	body, header, err := r.request(endpoint, queries)
+	if err != nil {
+		return "", err
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		return "", err
+	}
+	return header.Get("X-Next-Page"), nil
+}
+
+func (r *GitlabRepo) ReadFile(ref, path string) ([]byte, error) {
+	contents, _, err := r.request("/repository/files/"+url.PathEscape(path)+"/raw", url.Values{"ref": []string{ref}})
+	var apiErr *gitlabError
+	if errors.As(err, &apiErr) && apiErr.statusCode == http.StatusNotFound {
+		return nil, fileNotFound(ref, path)
+	}
+	return contents, err
+}
+
+// gitlabError is returned for unsuccessful responses of the Gitlab API
+type gitlabError struct {
+	statusCode int
+	status     string
+	body       string
+}
+
+func (e *gitlabError) Error() string {
+	return fmt.Sprintf("%d: %s: %s", e.statusCode, e.status, e.body)
+}
+
+// request sends a GET request to the given endpoint of the project, returning the body and headers of the response
+func (r *GitlabRepo) request(endpoint string, queries url.Values) ([]byte, http.Header, error) {
+	reqURL := fmt.Sprintf("%s/projects/%s%s?%s", r.apiURL, url.PathEscape(r.project), endpoint, queries.Encode())
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Add("Accept", "application/json")
+	if r.token != "" {
+		req.Header.Add("PRIVATE-TOKEN", r.token)
+	}
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.StatusCode > 299 {
+		return nil, nil, &gitlabError{statusCode: res.StatusCode, status: res.Status, body: string(body)}
+	}
+	return body, res.Header, nil
+}
+
+// getGitlabAPIToken reads the token for the given host from ~/.gitlab/<host>/token, falling back to ~/.gitlab/token
+func getGitlabAPIToken(host string) (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	creds, err := os.ReadFile(filepath.Join(homedir, ".gitlab", host, "token"))
+	if os.IsNotExist(err) {
+		creds, err = os.ReadFile(filepath.Join(homedir, ".gitlab/token"))
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(creds)), nil
+}
@@ -0,0 +1,114 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGitlabRepo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.EscapedPath(), "/api/v4/projects/group%2Foperator/") {
+			t.Errorf("project path is not escaped: %s", r.URL.EscapedPath())
+		}
+		switch r.URL.Path {
+		case "/api/v4/projects/group/operator/repository/tags":
+			fmt.Fprint(w, `[{"name":"v1.0.0","commit":{"id":"aaa"}}]`)
+		case "/api/v4/projects/group/operator/repository/commits":
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("X-Next-Page", "2")
+				fmt.Fprint(w, `[{"id":"ddd","message":"Merge WINC-2","parent_ids":["ccc","bbb"]},`+
+					`{"id":"ccc","message":"WINC-2: fix","parent_ids":["bbb"]}]`)
+				return
+			}
+			fmt.Fprint(w, `[{"id":"bbb","message":"Merge WINC-1","parent_ids":["aaa","zzz"]},`+
+				`{"id":"aaa","message":"initial","parent_ids":[]}]`)
+		case "/api/v4/projects/group/operator/repository/files/build/go.mod/raw":
+			if !strings.Contains(r.URL.EscapedPath(), "files/build%2Fgo.mod/raw") || r.URL.Query().Get("ref") != "v1.0.0" {
+				t.Errorf("unexpected file request %s", r.URL)
+			}
+			fmt.Fprint(w, "module example.com/operator\n")
+		case "/api/v4/projects/group/operator/repository/merge_base":
+			if refs := r.URL.Query()["refs[]"]; len(refs) != 2 {
+				t.Errorf("unexpected refs %v", refs)
+			}
+			fmt.Fprint(w, `{"id":"bbb"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	repo := &GitlabRepo{apiURL: server.URL + "/api/v4", project: "group/operator", client: server.Client()}
+
+	tags, err := repo.GetTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 1 || tags[0].Name != "v1.0.0" || tags[0].Sha != "aaa" {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+	merges, err := repo.ListCommits("main", "aaa", IsMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merges) != 2 || merges[0].SHA != "ddd" || merges[1].SHA != "bbb" {
+		t.Errorf("unexpected merges: %v", merges)
+	}
+	base, err := repo.MergeBase("main", "release-1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base != "bbb" {
+		t.Errorf("unexpected merge base %s", base)
+	}
+	contents, err := repo.ReadFile("v1.0.0", "build/go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(contents) != "module example.com/operator\n" {
+		t.Errorf("unexpected contents %q", contents)
+	}
+	if _, err = repo.ReadFile("v1.0.0", "go.sum"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected missing file error, got %v", err)
+	}
+}
+
+func TestNewRepoProviders(t *testing.T) {
+	testCases := []struct {
+		url         string
+		expected    string
+		expectedErr bool
+	}{
+		{url: "https://github.com/openshift/windows-machine-config-operator", expected: "*git.GithubRepo"},
+		{url: "https://gitlab.com/group/subgroup/operator.git", expected: "*git.GitlabRepo"},
+		{url: "https://git.example.com/group/operator", expected: "*git.GitlabRepo"},
+		{url: "https://unknown.example.com/group/operator", expectedErr: true},
+	}
+	saved := maps.Clone(providerHosts)
+	t.Cleanup(func() { providerHosts = saved })
+	if err := RegisterHost("git.example.com", ProviderGitlab); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			repo, err := NewRepo(tc.url)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := fmt.Sprintf("%T", repo); actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalRepo is a Repo backed by a clone on the local filesystem, accessed through the git binary
+type LocalRepo struct {
+	path string
+}
+
+// NewLocalRepo returns a Repo for the checkout or bare clone at the given path
+func NewLocalRepo(path string) (*LocalRepo, error) {
+	r := &LocalRepo{path: path}
+	if _, err := r.git("rev-parse", "--git-dir"); err != nil {
+		return nil, fmt.Errorf("%s is not a git repository: %w", path, err)
+	}
+	return r, nil
+}
+
+// NewCachedRepo returns a Repo for a bare clone of gitURL kept in cacheDir. The clone is created if it does not exist
+// yet, and otherwise fetched so that it is up to date with the remote.
+func NewCachedRepo(gitURL, cacheDir string) (*LocalRepo, error) {
+	if cacheDir == "" {
+		userCache, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		cacheDir = filepath.Join(userCache, "gojira", "repos")
+	}
+	u, err := url.Parse(gitURL)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(cacheDir, u.Host, strings.TrimSuffix(u.Path, ".git")+".git")
+	if _, err = os.Stat(path); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "cloning %s into %s\n", gitURL, path)
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if out, err := exec.Command("git", "clone", "--mirror", "--quiet", gitURL, path).CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("error cloning %s: %w: %s", gitURL, err, out)
+		}
+		return NewLocalRepo(path)
+	} else if err != nil {
+		return nil, err
+	}
+	r, err := NewLocalRepo(path)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "fetching %s into %s\n", gitURL, path)
+	if _, err = r.git("fetch", "--quiet", "--prune", "--tags", "origin"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *LocalRepo) GetTags() ([]Tag, error) {
+	// *objectname is the commit an annotated tag points to, and is empty for lightweight tags
+	out, err := r.git("for-each-ref", "--format=%(refname:short) %(objectname) %(*objectname)", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+	tagList := make([]Tag, 0)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		tag := Tag{Name: fields[0], Sha: fields[1]}
+		if len(fields) == 3 {
+			tag.Sha = fields[2]
+		}
+		tagList = append(tagList, tag)
+	}
+	return tagList, nil
+}
+
+// ListCommits returns the commits reachable from startSHA which are not reachable from endSHA, newest first
+func (r *LocalRepo) ListCommits(startSHA, endSHA string, filter FilterFunction) ([]Commit, error) {
+	start, err := r.resolve(startSHA)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"log", "--format=%H%x00%P%x00%cI%x00%B%x1e", start}
+	if endSHA != "" {
+		end, err := r.resolve(endSHA)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "^"+end)
+	}
+	out, err := r.git(args...)
+	if err != nil {
+		return nil, err
+	}
+	var commitList []Commit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, err
+		}
+		commit := Commit{
+			SHA:     fields[0],
+			Parents: strings.Fields(fields[1]),
+			Date:    date,
+			Message: strings.TrimSpace(fields[3]),
+		}
+		if filter(commit) {
+			commitList = append(commitList, commit)
+		}
+	}
+	return commitList, nil
+}
+
+func (r *LocalRepo) MergeBase(sha1, sha2 string) (string, error) {
+	rev1, err := r.resolve(sha1)
+	if err != nil {
+		return "", err
+	}
+	rev2, err := r.resolve(sha2)
+	if err != nil {
+		return "", err
+	}
+	out, err := r.git("merge-base", rev1, rev2)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// resolve returns the commit SHA for the given revision. Branch names which only exist as remote tracking branches in
+// a regular checkout are resolved through the origin remote.
+func (r *LocalRepo) resolve(rev string) (string, error) {
+	out, err := r.git("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err == nil {
+		return strings.TrimSpace(out), nil
+	}
+	out, err = r.git("rev-parse", "--verify", "--quiet", "origin/"+rev+"^{commit}")
+	if err == nil {
+		return strings.TrimSpace(out), nil
+	}
+	return "", fmt.Errorf("unknown revision %s in %s", rev, r.path)
+}
+
+func (r *LocalRepo) ReadFile(ref, path string) ([]byte, error) {
+	commit, err := r.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+	// cat-file -e fails without output if the object does not exist
+	if _, err = r.git("cat-file", "-e", commit+":"+path); err != nil {
+		return nil, fileNotFound(ref, path)
+	}
+	out, err := r.git("show", commit+":"+path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+// git runs the git binary against the repository, and returns its standard output
+func (r *LocalRepo) git(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", r.path}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,166 @@
+package git
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestRepo creates a repository with the history:
+//
+//	main:    initial -- tagged (v1.0.0) -- merge
+//	                        \            /
+//	feature:                 feature-work
+func newTestRepo(t *testing.T) (*LocalRepo, map[string]string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	shas := make(map[string]string)
+	run("init", "--quiet", "--initial-branch=main")
+	run("commit", "--quiet", "--allow-empty", "-m", "initial")
+	shas["initial"] = run("rev-parse", "HEAD")
+	run("commit", "--quiet", "--allow-empty", "-m", "tagged")
+	shas["tagged"] = run("rev-parse", "HEAD")
+	run("tag", "-a", "v1.0.0", "-m", "v1.0.0")
+	run("checkout", "--quiet", "-b", "feature")
+	run("commit", "--quiet", "--allow-empty", "-m", "WINC-1: feature work")
+	shas["feature"] = run("rev-parse", "HEAD")
+	run("checkout", "--quiet", "main")
+	run("merge", "--quiet", "--no-ff", "-m", "Merge WINC-1", "feature")
+	shas["merge"] = run("rev-parse", "HEAD")
+
+	repo, err := NewLocalRepo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo, shas
+}
+
+func TestLocalRepo(t *testing.T) {
+	repo, shas := newTestRepo(t)
+
+	tags, err := repo.GetTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 1 || tags[0].Name != "v1.0.0" || tags[0].Sha != shas["tagged"] {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+
+	all := func(Commit) bool { return true }
+	commits, err := repo.ListCommits("main", shas["tagged"], all)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[0].SHA != shas["merge"] || commits[1].SHA != shas["feature"] {
+		t.Errorf("unexpected commits: %v", commits)
+	}
+
+	merges, err := repo.ListCommits("main", shas["initial"], IsMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merges) != 1 || merges[0].Message != "Merge WINC-1" {
+		t.Errorf("unexpected merges: %v", merges)
+	}
+
+	base, err := repo.MergeBase("feature", shas["tagged"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base != shas["tagged"] {
+		t.Errorf("expected merge base %s, got %s", shas["tagged"], base)
+	}
+}
+
+func TestFirstTagContaining(t *testing.T) {
+	repo, shas := newTestRepo(t)
+	// v1.0.1 is on a release branch, and is not an ancestor of v1.1.0
+	for _, args := range [][]string{
+		{"tag", "v1.1.0", shas["merge"]},
+		{"checkout", "--quiet", "-b", "release-1.0", shas["tagged"]},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m",
+			"WINC-2: backport"},
+		{"tag", "v1.0.1"},
+	} {
+		if _, err := repo.git(args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tag, _, err := FirstTagContaining(repo, Mentions("WINC-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag == nil || tag.Name != "v1.0.1" {
+		t.Fatalf("expected v1.0.1, got %v", tag)
+	}
+
+	tag, commit, err := FirstTagContaining(repo, Mentions("WINC-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag == nil || tag.Name != "v1.1.0" || commit.SHA != shas["merge"] {
+		t.Fatalf("expected v1.1.0 at the merge, got %v %v", tag, commit)
+	}
+	if commit.Date.IsZero() {
+		t.Error("expected the commit date to be set")
+	}
+
+	// The oldest tag has no preceding tag, and its whole history is searched
+	tag, commit, err = FirstTagContaining(repo, Mentions("initial"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag == nil || tag.Name != "v1.0.0" || commit.SHA != shas["initial"] {
+		t.Fatalf("expected v1.0.0 at the initial commit, got %v %v", tag, commit)
+	}
+
+	tag, _, err = FirstTagContaining(repo, Mentions("WINC-12"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag != nil {
+		t.Errorf("expected WINC-12 not to match WINC-1, got %v", tag)
+	}
+}
+
+func TestLocalRepoReadFile(t *testing.T) {
+	repo, shas := newTestRepo(t)
+	if err := os.WriteFile(filepath.Join(repo.path, "go.mod"), []byte("module example.com/operator\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.git("add", "go.mod"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.git("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m",
+		"add go.mod"); err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := repo.ReadFile("main", "go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(contents) != "module example.com/operator\n" {
+		t.Errorf("unexpected contents %q", contents)
+	}
+	if _, err = repo.ReadFile(shas["merge"], "go.mod"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected file to not exist before it was added, got %v", err)
+	}
+}
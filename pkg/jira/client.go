@@ -0,0 +1,161 @@
+package jira
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultBaseURL is the Jira instance used when no other is configured
+	DefaultBaseURL = "https://issues.redhat.com"
+	// DefaultAPIVersion is the version of the Jira REST API requests are made against
+	DefaultAPIVersion = "2"
+)
+
+// DefaultClient is the client used by the package level functions. It talks to DefaultBaseURL using the personal
+// access token stored in ~/.jira/token.
+var DefaultClient = NewClient(DefaultBaseURL, &TokenFileAuth{})
+
+// Authenticator adds credentials to a request before it is sent
+type Authenticator interface {
+	Authenticate(*http.Request) error
+}
+
+// BearerTokenAuth authenticates using a personal access token
+type BearerTokenAuth struct {
+	Token string
+}
+
+func (a *BearerTokenAuth) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// TokenFileAuth authenticates using a personal access token read from a file. If Path is empty, ~/.jira/token is used.
+type TokenFileAuth struct {
+	Path string
+}
+
+func (a *TokenFileAuth) Authenticate(req *http.Request) error {
+	token, err := a.token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (a *TokenFileAuth) token() (string, error) {
+	path := a.Path
+	if path == "" {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(homedir, ".jira/token")
+	}
+	creds, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(creds)), nil
+}
+
+// BasicAuth authenticates using a username and password or API token
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+func (a *BasicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// Client makes requests against the REST API of a single Jira instance
+type Client struct {
+	// BaseURL is the root of the Jira instance, e.g. https://issues.redhat.com
+	BaseURL string
+	// APIVersion is the version of the REST API to use, e.g. 2
+	APIVersion string
+	// HTTPClient is used to make requests. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+	// Auth adds credentials to each request. If nil, requests are made anonymously.
+	Auth Authenticator
+}
+
+// NewClient returns a client for the Jira instance at baseURL using the default API version
+func NewClient(baseURL string, auth Authenticator) *Client {
+	return &Client{
+		BaseURL:    baseURL,
+		APIVersion: DefaultAPIVersion,
+		HTTPClient: http.DefaultClient,
+		Auth:       auth,
+	}
+}
+
+// Host returns the hostname of the Jira instance, e.g. issues.redhat.com
+func (c *Client) Host() string {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return c.BaseURL
+	}
+	return u.Host
+}
+
+func (c *Client) constructURL(endpoint string, queries url.Values) (*url.URL, error) {
+	apiVersion := c.APIVersion
+	if apiVersion == "" {
+		apiVersion = DefaultAPIVersion
+	}
+	joined, err := url.JoinPath(c.BaseURL, "rest/api", apiVersion, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	apiURL, err := url.Parse(joined)
+	if err != nil {
+		return nil, err
+	}
+	if queries != nil {
+		apiURL.RawQuery = queries.Encode()
+	}
+	return apiURL, nil
+}
+
+// apiRequest makes the request, returns the response body
+func (c *Client) apiRequest(httpMethod, url string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(httpMethod, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", "application/json")
+	if c.Auth != nil {
+		if err = c.Auth.Authenticate(req); err != nil {
+			return nil, err
+		}
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode > 299 {
+		return nil, fmt.Errorf("%d: %s: %s", res.StatusCode, res.Status, string(resBody))
+	}
+	return resBody, nil
+}
@@ -0,0 +1,103 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Comment is a comment on an issue
+type Comment struct {
+	ID      string `json:"id,omitempty"`
+	Body    string `json:"body"`
+	Author  *User  `json:"author,omitempty"`
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+type User struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+type commentPage struct {
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Comments   []Comment `json:"comments"`
+}
+
+// GetComments returns the comments of the issue using the default client
+func GetComments(issueKey string) ([]Comment, error) {
+	return DefaultClient.GetComments(issueKey)
+}
+
+// GetComments returns all comments of the issue, oldest first, following pagination
+func (c *Client) GetComments(issueKey string) ([]Comment, error) {
+	var comments []Comment
+	for {
+		commentsURL, err := c.constructURL("/issue/"+url.PathEscape(issueKey)+"/comment", url.Values{
+			"startAt": []string{strconv.Itoa(len(comments))},
+		})
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.apiRequest(http.MethodGet, commentsURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		var page commentPage
+		if err = json.Unmarshal(body, &page); err != nil {
+			return nil, err
+		}
+		comments = append(comments, page.Comments...)
+		if len(page.Comments) == 0 || len(comments) >= page.Total {
+			return comments, nil
+		}
+	}
+}
+
+// AddComment adds a comment to the issue using the default client
+func AddComment(issueKey, body string) (*Comment, error) {
+	return DefaultClient.AddComment(issueKey, body)
+}
+
+// AddComment adds a comment with the given body to the issue, and returns the created comment
+func (c *Client) AddComment(issueKey, body string) (*Comment, error) {
+	commentURL, err := c.constructURL("/issue/"+url.PathEscape(issueKey)+"/comment", nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendComment(http.MethodPost, commentURL.String(), body)
+}
+
+// EditComment replaces the body of a comment using the default client
+func EditComment(issueKey, id, body string) (*Comment, error) {
+	return DefaultClient.EditComment(issueKey, id, body)
+}
+
+// EditComment replaces the body of the comment with the given ID, and returns the updated comment
+func (c *Client) EditComment(issueKey, id, body string) (*Comment, error) {
+	commentURL, err := c.constructURL("/issue/"+url.PathEscape(issueKey)+"/comment/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendComment(http.MethodPut, commentURL.String(), body)
+}
+
+func (c *Client) sendComment(httpMethod, commentURL, body string) (*Comment, error) {
+	reqBody, err := json.Marshal(&Comment{Body: body})
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.apiRequest(httpMethod, commentURL, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	var comment Comment
+	if err = json.Unmarshal(res, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
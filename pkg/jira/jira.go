@@ -1,14 +1,13 @@
 package jira
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
+	"iter"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -24,7 +23,23 @@ type IssuePriorityName string
 const MajorPriority IssuePriorityName = "Major"
 
 type IssueSearch struct {
-	Issues []Issue `json:"issues"`
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []Issue `json:"issues"`
+}
+
+// SearchOptions modifies the behavior of a search. The zero value returns every matching issue with all fields.
+type SearchOptions struct {
+	// Fields limits the fields returned for each issue, e.g. []string{"summary", "fixVersions"}
+	Fields []string
+	// PageSize is the number of issues requested per page. If 0, the server default is used.
+	PageSize int
+	// Limit is the maximum number of issues returned. If 0, all matching issues are returned.
+	Limit int
+	// ValidateQuery sets how strictly the query is validated: "strict", "warn" or "none". With "warn", references to
+	// issues which do not exist do not cause the search to fail. If empty, the server default is used.
+	ValidateQuery string
 }
 
 type Issue struct {
@@ -46,6 +61,36 @@ type IssueFields struct {
 	EpicLink      string          `json:"customfield_12311140,omitempty"`
 	Labels        []string        `json:"labels,omitempty"`
 	Priority      *Priority       `json:"priority,omitempty"`
+	Status        *Status         `json:"status,omitempty"`
+	// Custom holds the raw value of every custom field returned for the issue, by ID, e.g. customfield_12324749
+	Custom map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields of an issue, and keeps the raw values of all custom fields
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	type plainFields IssueFields
+	if err := json.Unmarshal(data, (*plainFields)(f)); err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for name, value := range raw {
+		if !strings.HasPrefix(name, "customfield_") || string(value) == "null" {
+			continue
+		}
+		if f.Custom == nil {
+			f.Custom = make(map[string]json.RawMessage)
+		}
+		f.Custom[name] = value
+	}
+	return nil
+}
+
+// Status is the workflow status of an issue. It is set by Jira, and ignored when creating or updating issues.
+type Status struct {
+	Name string `json:"name"`
 }
 
 type Priority struct {
@@ -77,12 +122,85 @@ type IssueCreationResponse struct {
 	Key string `json:"key"`
 }
 
+// Search returns all issues matching the given JQL query using the default client
 func Search(query string) ([]Issue, error) {
-	searchURL, err := constructURL("/search", url.Values{"jql": []string{query}})
+	return DefaultClient.Search(query)
+}
+
+// Search returns all issues matching the given JQL query
+func (c *Client) Search(query string) ([]Issue, error) {
+	return c.SearchWithOptions(query, nil)
+}
+
+// SearchWithOptions returns the issues matching the given JQL query, following pagination until all results, or
+// opts.Limit results, have been collected
+func (c *Client) SearchWithOptions(query string, opts *SearchOptions) ([]Issue, error) {
+	var issues []Issue
+	for issue, err := range c.SearchIter(query, opts) {
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// SearchIter returns an iterator over the issues matching the given JQL query. Pages are requested as the iterator
+// is consumed, so large result sets do not have to be held in memory. Iteration stops after the first error.
+func (c *Client) SearchIter(query string, opts *SearchOptions) iter.Seq2[Issue, error] {
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+	return func(yield func(Issue, error) bool) {
+		returned := 0
+		startAt := 0
+		for {
+			page, err := c.searchPage(query, startAt, opts)
+			if err != nil {
+				yield(Issue{}, err)
+				return
+			}
+			for _, issue := range page.Issues {
+				if !yield(issue, nil) {
+					return
+				}
+				returned++
+				if opts.Limit > 0 && returned >= opts.Limit {
+					return
+				}
+			}
+			startAt = page.StartAt + len(page.Issues)
+			if len(page.Issues) == 0 || startAt >= page.Total {
+				return
+			}
+		}
+	}
+}
+
+// searchPage returns a single page of search results beginning at the result with index startAt
+func (c *Client) searchPage(query string, startAt int, opts *SearchOptions) (*IssueSearch, error) {
+	queries := url.Values{
+		"jql":     []string{query},
+		"startAt": []string{strconv.Itoa(startAt)},
+	}
+	pageSize := opts.PageSize
+	if opts.Limit > 0 && (pageSize == 0 || opts.Limit < pageSize) {
+		pageSize = opts.Limit
+	}
+	if pageSize > 0 {
+		queries.Set("maxResults", strconv.Itoa(pageSize))
+	}
+	if len(opts.Fields) > 0 {
+		queries.Set("fields", strings.Join(opts.Fields, ","))
+	}
+	if opts.ValidateQuery != "" {
+		queries.Set("validateQuery", opts.ValidateQuery)
+	}
+	searchURL, err := c.constructURL("/search", queries)
 	if err != nil {
 		return nil, err
 	}
-	body, err := apiRequest(http.MethodGet, searchURL.String(), nil)
+	body, err := c.apiRequest(http.MethodGet, searchURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +208,7 @@ func Search(query string) ([]Issue, error) {
 	if err = json.Unmarshal(body, &searchResults); err != nil {
 		return nil, err
 	}
-	return searchResults.Issues, nil
+	return &searchResults, nil
 }
 
 type remoteLink struct {
@@ -104,11 +222,15 @@ type remoteLinkDetails struct {
 }
 
 func GetRemoteLinks(issueKey string) ([]remoteLink, error) {
-	remoteLinkURL, err := constructURL("/issue/"+issueKey+"/remotelink", nil)
+	return DefaultClient.GetRemoteLinks(issueKey)
+}
+
+func (c *Client) GetRemoteLinks(issueKey string) ([]remoteLink, error) {
+	remoteLinkURL, err := c.constructURL("/issue/"+issueKey+"/remotelink", nil)
 	if err != nil {
 		return nil, err
 	}
-	body, err := apiRequest(http.MethodGet, remoteLinkURL.String(), nil)
+	body, err := c.apiRequest(http.MethodGet, remoteLinkURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +243,11 @@ func GetRemoteLinks(issueKey string) ([]remoteLink, error) {
 }
 
 func AddRemoteLink(issueKey, linkURL, title string) error {
-	remoteLinkURL, err := constructURL("/issue/"+issueKey+"/remotelink", nil)
+	return DefaultClient.AddRemoteLink(issueKey, linkURL, title)
+}
+
+func (c *Client) AddRemoteLink(issueKey, linkURL, title string) error {
+	remoteLinkURL, err := c.constructURL("/issue/"+issueKey+"/remotelink", nil)
 	if err != nil {
 		return err
 	}
@@ -135,45 +261,17 @@ func AddRemoteLink(issueKey, linkURL, title string) error {
 	if err != nil {
 		return err
 	}
-	body, err := apiRequest(http.MethodPost, remoteLinkURL.String(), reqBody)
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(body))
-	return nil
-
-}
+	_, err = c.apiRequest(http.MethodPost, remoteLinkURL.String(), reqBody)
+	return err
 
-func constructURL(endpoint string, queries url.Values) (*url.URL, error) {
-	apiURL := "https://issues.redhat.com/rest/api/2"
-	joined, err := url.JoinPath(apiURL, endpoint)
-	if err != nil {
-		return nil, err
-	}
-	searchURL, err := url.Parse(joined)
-	if err != nil {
-		return nil, err
-	}
-	if queries != nil {
-		searchURL.RawQuery = queries.Encode()
-	}
-	return searchURL, nil
 }
 
-func getJIRAAPIToken() (string, error) {
-	homedir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	creds, err := os.ReadFile(filepath.Join(homedir, ".jira/token"))
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(string(creds)), nil
+func CreateIssue(issue *Issue) (*IssueCreationResponse, error) {
+	return DefaultClient.CreateIssue(issue)
 }
 
-func CreateIssue(issue *Issue) (*IssueCreationResponse, error) {
-	createIssueURL, err := constructURL("/issue", nil)
+func (c *Client) CreateIssue(issue *Issue) (*IssueCreationResponse, error) {
+	createIssueURL, err := c.constructURL("/issue", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +279,7 @@ func CreateIssue(issue *Issue) (*IssueCreationResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	res, err := apiRequest(http.MethodPost, createIssueURL.String(), issueBytes)
+	res, err := c.apiRequest(http.MethodPost, createIssueURL.String(), issueBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -191,19 +289,63 @@ func CreateIssue(issue *Issue) (*IssueCreationResponse, error) {
 }
 
 func UpdateIssue(key, updateBody string) error {
-	updateIssueURL, err := constructURL("/issue/"+key, nil)
+	return DefaultClient.UpdateIssue(key, updateBody)
+}
+
+func (c *Client) UpdateIssue(key, updateBody string) error {
+	updateIssueURL, err := c.constructURL("/issue/"+key, nil)
 	if err != nil {
 		return err
 	}
-	_, err = apiRequest(http.MethodPut, updateIssueURL.String(), []byte(updateBody))
+	_, err = c.apiRequest(http.MethodPut, updateIssueURL.String(), []byte(updateBody))
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// issueChunkSize is the number of keys looked up in a single search by GetIssues, keeping the request URL to a
+// reasonable length
+const issueChunkSize = 50
+
+// GetIssues returns the issues with the given keys using the default client
+func GetIssues(issueKeys []string) ([]Issue, []string, error) {
+	return DefaultClient.GetIssues(issueKeys)
+}
+
+// GetIssues returns the issues with the given keys, in the order of the given keys, looking them up in chunks rather
+// than one request per issue. Keys that do not exist or are not visible to the user are returned as missing instead of
+// causing an error.
+func (c *Client) GetIssues(issueKeys []string) ([]Issue, []string, error) {
+	found := make(map[string]Issue)
+	for chunk := range slices.Chunk(issueKeys, issueChunkSize) {
+		results, err := c.SearchWithOptions(fmt.Sprintf("key in (%s)", strings.Join(chunk, ",")),
+			&SearchOptions{ValidateQuery: "warn"})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, issue := range results {
+			found[issue.Key] = issue
+		}
+	}
+	var issues []Issue
+	var missing []string
+	for _, key := range issueKeys {
+		if issue, ok := found[key]; ok {
+			issues = append(issues, issue)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	return issues, missing, nil
+}
+
 func GetIssue(issueKey string) (*Issue, error) {
-	results, err := Search("key = " + issueKey)
+	return DefaultClient.GetIssue(issueKey)
+}
+
+func (c *Client) GetIssue(issueKey string) (*Issue, error) {
+	results, err := c.Search("key = " + issueKey)
 	if err != nil {
 		return nil, err
 	}
@@ -215,30 +357,3 @@ func GetIssue(issueKey string) (*Issue, error) {
 	}
 	return &results[0], nil
 }
-
-// apiRequest makes the request, returns the response body
-func apiRequest(httpMethod, url string, body []byte) ([]byte, error) {
-	apiKey, err := getJIRAAPIToken()
-	if err != nil {
-		return nil, err
-	}
-	req, err := http.NewRequest(httpMethod, url, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Authorization", "Bearer "+apiKey)
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	resBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	if res.StatusCode > 299 {
-		return nil, fmt.Errorf("%d: %s: %s", res.StatusCode, res.Status, string(resBody))
-	}
-	return resBody, nil
-}
@@ -1,7 +1,13 @@
 package jira
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -15,3 +21,235 @@ func TestSearch(t *testing.T) {
 		fmt.Printf("%s\t%s\thttps://issues.redhat.com/browse/%s\n", issue.Key, issue.Fields.EpicName, issue.Key)
 	}
 }
+
+func TestClientSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if jql := r.URL.Query().Get("jql"); jql != "key = WINC-1" {
+			t.Errorf("unexpected jql %s", jql)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("unexpected authorization header %s", auth)
+		}
+		fmt.Fprint(w, `{"issues":[{"key":"WINC-1","fields":{"summary":"test issue"}}]}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, &BearerTokenAuth{Token: "secret"})
+	issues, err := c.Search("key = WINC-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Key != "WINC-1" || issues[0].Fields.Summary != "test issue" {
+		t.Errorf("unexpected search results: %v", issues)
+	}
+}
+
+func TestClientSearchPagination(t *testing.T) {
+	const total = 5
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		if fields := r.URL.Query().Get("fields"); fields != "summary,status" {
+			t.Errorf("unexpected fields %s", fields)
+		}
+		var issues []string
+		for i := startAt; i < startAt+maxResults && i < total; i++ {
+			issues = append(issues, fmt.Sprintf(`{"key":"WINC-%d"}`, i))
+		}
+		fmt.Fprintf(w, `{"startAt":%d,"maxResults":%d,"total":%d,"issues":[%s]}`, startAt, maxResults, total,
+			strings.Join(issues, ","))
+	}))
+	defer server.Close()
+	c := NewClient(server.URL, nil)
+
+	testCases := []struct {
+		name     string
+		opts     SearchOptions
+		expected int
+	}{
+		{name: "all pages", opts: SearchOptions{PageSize: 2}, expected: 5},
+		{name: "limit within a page", opts: SearchOptions{PageSize: 2, Limit: 1}, expected: 1},
+		{name: "limit across pages", opts: SearchOptions{PageSize: 2, Limit: 3}, expected: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.Fields = []string{"summary", "status"}
+			issues, err := c.SearchWithOptions("project = WINC", &tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(issues) != tc.expected {
+				t.Fatalf("expected %d issues, got %d", tc.expected, len(issues))
+			}
+			for i, issue := range issues {
+				if issue.Key != fmt.Sprintf("WINC-%d", i) {
+					t.Errorf("unexpected issue %s at index %d", issue.Key, i)
+				}
+			}
+		})
+	}
+}
+
+func TestClientGetIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if jql := r.URL.Query().Get("jql"); jql != "key in (WINC-1,OCPBUGS-2,WINC-3)" {
+			t.Errorf("unexpected jql %s", jql)
+		}
+		if validate := r.URL.Query().Get("validateQuery"); validate != "warn" {
+			t.Errorf("unexpected validateQuery %s", validate)
+		}
+		fmt.Fprint(w, `{"startAt":0,"total":2,"issues":[{"key":"WINC-3","fields":{"summary":"fix",`+
+			`"customfield_12324749":"CVE-2025-1234","customfield_12324750":null}},{"key":"WINC-1"}],`+
+			`"warningMessages":["An issue with key 'OCPBUGS-2' does not exist for field 'key'."]}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, nil)
+	issues, missing, err := c.GetIssues([]string{"WINC-1", "OCPBUGS-2", "WINC-3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].Key != "WINC-1" || issues[1].Key != "WINC-3" {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+	if len(missing) != 1 || missing[0] != "OCPBUGS-2" {
+		t.Errorf("unexpected missing issues: %v", missing)
+	}
+	custom := issues[1].Fields.Custom
+	if issues[1].Fields.Summary != "fix" || len(custom) != 1 || string(custom["customfield_12324749"]) != `"CVE-2025-1234"` {
+		t.Errorf("unexpected fields: %+v", issues[1].Fields)
+	}
+}
+
+func TestClientTransitionIssue(t *testing.T) {
+	var posted transitionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/OCPBUGS-1/transitions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"transitions":[{"id":"11","name":"Verify","to":{"name":"Verified"}},`+
+				`{"id":"21","name":"Close Issue","to":{"name":"Closed"}}]}`)
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+				t.Error(err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, nil)
+	err := c.TransitionIssue("OCPBUGS-1", "closed", &TransitionOptions{Resolution: "Done", Comment: "Released"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if posted.Transition.ID != "21" {
+		t.Errorf("expected transition 21, got %s", posted.Transition.ID)
+	}
+	if posted.Fields == nil || posted.Fields.Resolution.Name != "Done" {
+		t.Errorf("unexpected fields %+v", posted.Fields)
+	}
+	if posted.Update == nil || len(posted.Update.Comment) != 1 || posted.Update.Comment[0].Add.Body != "Released" {
+		t.Errorf("unexpected update %+v", posted.Update)
+	}
+
+	err = c.TransitionIssue("OCPBUGS-1", "Release Pending", nil)
+	if err == nil || !strings.Contains(err.Error(), "Verify (to Verified)") {
+		t.Errorf("expected error listing the available transitions, got %v", err)
+	}
+}
+
+func TestClientVersions(t *testing.T) {
+	var created Version
+	var updated string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/project/WINC/versions":
+			fmt.Fprint(w, `[{"id":"1","name":"WMCO 10.19.0","released":true,"releaseDate":"2025-06-10"}]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/version":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Error(err)
+			}
+			fmt.Fprint(w, `{"id":"2","name":"WMCO 10.19.1"}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/2/issue/WINC-1":
+			body, _ := io.ReadAll(r.Body)
+			updated = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, nil)
+	versions, err := c.GetVersions("WINC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 || versions[0].Name != "WMCO 10.19.0" || !versions[0].Released {
+		t.Errorf("unexpected versions %+v", versions)
+	}
+	version, err := c.CreateVersion(&Version{Name: "WMCO 10.19.1", Project: "WINC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.ID != "2" || created.Project != "WINC" || created.Name != "WMCO 10.19.1" {
+		t.Errorf("unexpected version %+v created from %+v", version, created)
+	}
+	if err = c.SetFixVersions("WINC-1", []string{"WMCO 10.19.1"}); err != nil {
+		t.Fatal(err)
+	}
+	if updated != `{"fields":{"fixVersions":[{"name":"WMCO 10.19.1"}]}}` {
+		t.Errorf("unexpected update %s", updated)
+	}
+}
+
+func TestClientComments(t *testing.T) {
+	var posted Comment
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/WINC-1/comment":
+			// Serve one comment per page
+			startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+			fmt.Fprintf(w, `{"startAt":%d,"maxResults":1,"total":2,"comments":[{"id":"%d","body":"comment %d"}]}`,
+				startAt, startAt+10, startAt)
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/WINC-1/comment",
+			r.Method == http.MethodPut && r.URL.Path == "/rest/api/2/issue/WINC-1/comment/10":
+			if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+				t.Error(err)
+			}
+			fmt.Fprintf(w, `{"id":"10","body":%q}`, posted.Body)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, nil)
+	comments, err := c.GetComments("WINC-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 || comments[0].ID != "10" || comments[1].Body != "comment 1" {
+		t.Errorf("unexpected comments %+v", comments)
+	}
+	added, err := c.AddComment("WINC-1", "Shipped")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.ID != "10" || posted.Body != "Shipped" {
+		t.Errorf("unexpected comment %+v", added)
+	}
+	edited, err := c.EditComment("WINC-1", "10", "Shipped again")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edited.Body != "Shipped again" {
+		t.Errorf("unexpected comment %+v", edited)
+	}
+}
@@ -0,0 +1,136 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Transition moves an issue from its current status to another one in its workflow
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// To is the status the issue is in after the transition
+	To Status `json:"to"`
+}
+
+// TransitionOptions are fields set while transitioning an issue. Fields left empty are not changed.
+type TransitionOptions struct {
+	// Resolution is the name of the resolution set, e.g. Done. Only transitions to a resolved status accept it.
+	Resolution string
+	// Comment is added to the issue as part of the transition
+	Comment string
+}
+
+type transitionList struct {
+	Transitions []Transition `json:"transitions"`
+}
+
+type transitionRequest struct {
+	Transition transitionID       `json:"transition"`
+	Fields     *transitionFields  `json:"fields,omitempty"`
+	Update     *transitionUpdates `json:"update,omitempty"`
+}
+
+type transitionID struct {
+	ID string `json:"id"`
+}
+
+type transitionFields struct {
+	Resolution resolution `json:"resolution"`
+}
+
+type resolution struct {
+	Name string `json:"name"`
+}
+
+type transitionUpdates struct {
+	Comment []commentUpdate `json:"comment"`
+}
+
+type commentUpdate struct {
+	Add commentBody `json:"add"`
+}
+
+type commentBody struct {
+	Body string `json:"body"`
+}
+
+// GetTransitions returns the transitions available for the issue using the default client
+func GetTransitions(issueKey string) ([]Transition, error) {
+	return DefaultClient.GetTransitions(issueKey)
+}
+
+// GetTransitions returns the transitions the user can make from the issue's current status
+func (c *Client) GetTransitions(issueKey string) ([]Transition, error) {
+	transitionsURL, err := c.constructURL("/issue/"+url.PathEscape(issueKey)+"/transitions", nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.apiRequest(http.MethodGet, transitionsURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var list transitionList
+	if err = json.Unmarshal(body, &list); err != nil {
+		return nil, err
+	}
+	return list.Transitions, nil
+}
+
+// TransitionIssue makes the named transition using the default client
+func TransitionIssue(issueKey, name string, opts *TransitionOptions) error {
+	return DefaultClient.TransitionIssue(issueKey, name, opts)
+}
+
+// TransitionIssue makes the transition of the issue with the given name, or leading to the status with the given name.
+// Names are compared case-insensitively. An error listing the available transitions is returned if none match.
+func (c *Client) TransitionIssue(issueKey, name string, opts *TransitionOptions) error {
+	transitions, err := c.GetTransitions(issueKey)
+	if err != nil {
+		return err
+	}
+	transition, err := findTransition(transitions, name)
+	if err != nil {
+		return fmt.Errorf("unable to transition %s: %w", issueKey, err)
+	}
+	request := transitionRequest{Transition: transitionID{ID: transition.ID}}
+	if opts != nil && opts.Resolution != "" {
+		request.Fields = &transitionFields{Resolution: resolution{Name: opts.Resolution}}
+	}
+	if opts != nil && opts.Comment != "" {
+		request.Update = &transitionUpdates{Comment: []commentUpdate{{Add: commentBody{Body: opts.Comment}}}}
+	}
+	reqBody, err := json.Marshal(&request)
+	if err != nil {
+		return err
+	}
+	transitionsURL, err := c.constructURL("/issue/"+url.PathEscape(issueKey)+"/transitions", nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.apiRequest(http.MethodPost, transitionsURL.String(), reqBody)
+	return err
+}
+
+// findTransition returns the transition with the given name, preferring a match on the transition's name over one on
+// the status it leads to
+func findTransition(transitions []Transition, name string) (*Transition, error) {
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].Name, name) {
+			return &transitions[i], nil
+		}
+	}
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].To.Name, name) {
+			return &transitions[i], nil
+		}
+	}
+	var available []string
+	for _, t := range transitions {
+		available = append(available, fmt.Sprintf("%s (to %s)", t.Name, t.To.Name))
+	}
+	return nil, fmt.Errorf("no transition %q, available transitions: %s", name, strings.Join(available, ", "))
+}
@@ -0,0 +1,128 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Version is a version of a project, which issues are targeted at or fixed in
+type Version struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Project is the key of the project the version belongs to. It is only used when creating a version.
+	Project string `json:"project,omitempty"`
+	// StartDate and ReleaseDate are formatted as YYYY-MM-DD
+	StartDate   string `json:"startDate,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	Archived    bool   `json:"archived"`
+	Released    bool   `json:"released"`
+}
+
+// GetVersions returns the versions of the project using the default client
+func GetVersions(project string) ([]Version, error) {
+	return DefaultClient.GetVersions(project)
+}
+
+// GetVersions returns all versions of the project, including archived and released ones
+func (c *Client) GetVersions(project string) ([]Version, error) {
+	versionsURL, err := c.constructURL("/project/"+url.PathEscape(project)+"/versions", nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.apiRequest(http.MethodGet, versionsURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var versions []Version
+	if err = json.Unmarshal(body, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// CreateVersion creates the version using the default client
+func CreateVersion(version *Version) (*Version, error) {
+	return DefaultClient.CreateVersion(version)
+}
+
+// CreateVersion creates the version in the project given by its Project field, and returns the created version
+func (c *Client) CreateVersion(version *Version) (*Version, error) {
+	versionURL, err := c.constructURL("/version", nil)
+	if err != nil {
+		return nil, err
+	}
+	reqBody, err := json.Marshal(version)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.apiRequest(http.MethodPost, versionURL.String(), reqBody)
+	if err != nil {
+		return nil, err
+	}
+	var created Version
+	if err = json.Unmarshal(body, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+type fixVersionsUpdate struct {
+	Fields fixVersionsFields `json:"fields"`
+}
+
+type fixVersionsFields struct {
+	FixVersions []FixVersion `json:"fixVersions"`
+}
+
+// SetFixVersions replaces the fix versions of the issue using the default client
+func SetFixVersions(issueKey string, names []string) error {
+	return DefaultClient.SetFixVersions(issueKey, names)
+}
+
+// SetFixVersions replaces the fix versions of the issue with the versions of its project with the given names
+func (c *Client) SetFixVersions(issueKey string, names []string) error {
+	update := fixVersionsUpdate{Fields: fixVersionsFields{FixVersions: []FixVersion{}}}
+	for _, name := range names {
+		update.Fields.FixVersions = append(update.Fields.FixVersions, FixVersion{Name: name})
+	}
+	updateBody, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	return c.UpdateIssue(issueKey, string(updateBody))
+}
+
+// VersionUpdate holds the fields of a version changed by UpdateVersion. Fields left nil or empty are not changed.
+type VersionUpdate struct {
+	Archived    *bool  `json:"archived,omitempty"`
+	Released    *bool  `json:"released,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+}
+
+// UpdateVersion updates the version with the given ID using the default client
+func UpdateVersion(id string, update *VersionUpdate) (*Version, error) {
+	return DefaultClient.UpdateVersion(id, update)
+}
+
+// UpdateVersion updates the version with the given ID, and returns the updated version
+func (c *Client) UpdateVersion(id string, update *VersionUpdate) (*Version, error) {
+	versionURL, err := c.constructURL("/version/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	reqBody, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.apiRequest(http.MethodPut, versionURL.String(), reqBody)
+	if err != nil {
+		return nil, err
+	}
+	var updated Version
+	if err = json.Unmarshal(body, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -0,0 +1,203 @@
+package konflux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	applicationv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sebsoto/gojira/pkg/git"
+	"github.com/sebsoto/gojira/pkg/jira"
+	"github.com/sebsoto/gojira/pkg/semver"
+	"github.com/sebsoto/gojira/pkg/vuln"
+)
+
+const (
+	// componentLabel names the component whose build created a snapshot
+	componentLabel = "appstudio.openshift.io/component"
+	// targetBranchAnnotation is the branch the build which created a snapshot was run for
+	targetBranchAnnotation = "build.appstudio.redhat.com/target_branch"
+)
+
+// ComponentChanges describes the changes made to a single component of the snapshot since its previous release
+type ComponentChanges struct {
+	// Name is the name of the component
+	Name string
+	// GitURL is the repository the component is built from
+	GitURL string
+	// Sha is the commit the component was built from in the snapshot
+	Sha string
+	// MissingMerges are merges made to the component's branch since the snapshot was built
+	MissingMerges []git.Commit
+	// Merges are the merges included in the release
+	Merges []git.Commit
+	// Issues are the Jira issues referenced by the merges
+	Issues []*jira.Issue
+	// Dependencies describes the changes to the Go dependencies of the component since the previous release. It is nil
+	// if the component is not a Go module, or dependencies were not checked.
+	Dependencies *vuln.Report
+	// issueKeys are the keys of the Jira issues referenced by the merges
+	issueKeys []string
+}
+
+// componentChanges lists the changes to a component of the snapshot. Repositories are looked up in repos, and added to
+// it when not yet present, so that components built from the same repository share it.
+func componentChanges(ctx context.Context, c client.Client, snap *applicationv1alpha1.Snapshot,
+	snapComponent applicationv1alpha1.SnapshotComponent, version semver.Semver, ticketRe *regexp.Regexp,
+	opts ReleaseOptions, repos map[string]git.Repo) (*ComponentChanges, error) {
+	changes := &ComponentChanges{
+		Name:   snapComponent.Name,
+		GitURL: snapComponent.Source.GitSource.URL,
+		Sha:    snapComponent.Source.GitSource.Revision,
+	}
+	var component applicationv1alpha1.Component
+	err := c.Get(ctx, types.NamespacedName{Name: snapComponent.Name, Namespace: snap.GetNamespace()}, &component)
+	if err != nil {
+		return nil, err
+	}
+	// The branch the component tracks is used, unless the snapshot was created by a build of this component
+	var branch string
+	if component.Spec.Source.GitSource != nil {
+		branch = component.Spec.Source.GitSource.Revision
+	}
+	if target, ok := snap.Annotations[targetBranchAnnotation]; ok && snap.Labels[componentLabel] == changes.Name {
+		branch = target
+	}
+
+	repo, ok := repos[changes.GitURL]
+	if !ok {
+		repo, err = git.NewRepo(changes.GitURL)
+		if err != nil {
+			return nil, err
+		}
+		repos[changes.GitURL] = repo
+	}
+	changes.MissingMerges, err = repo.ListCommits(branch, changes.Sha, opts.commitFilter())
+	if err != nil {
+		return nil, fmt.Errorf("error listing merges of component %s since its snapshot: %w", changes.Name, err)
+	}
+	base := opts.BaseCommitOverride
+	if base != "" {
+		changes.Merges, err = repo.ListCommits(changes.Sha, base, opts.commitFilter())
+	} else {
+		changes.Merges, base, err = commitsSinceLastRelease(repo, version, changes.Sha, branch, opts.BranchStrategy,
+			opts.commitFilter())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing merges of component %s: %w", changes.Name, err)
+	}
+	if opts.VulnDB != nil {
+		var dir string
+		if component.Spec.Source.GitSource != nil {
+			dir = component.Spec.Source.GitSource.Context
+		}
+		changes.Dependencies, err = checkDependencies(opts.VulnDB, repo, base, changes.Sha, dir)
+		if err != nil {
+			return nil, fmt.Errorf("error checking dependencies of component %s: %w", changes.Name, err)
+		}
+	}
+	for _, commit := range changes.Merges {
+		changes.issueKeys = append(changes.issueKeys, ticketRe.FindAllString(commit.Message, -1)...)
+	}
+	slices.Sort(changes.issueKeys)
+	changes.issueKeys = slices.Compact(changes.issueKeys)
+	return changes, nil
+}
+
+// checkDependencies compares the Go dependencies of the module in the given directory of the repository at the base
+// and head commits. If the module does not exist at head, nil is returned. If it does not exist at the base, or there
+// is no base, all dependencies are considered added.
+func checkDependencies(db *vuln.Database, repo git.Repo, base, head, dir string) (*vuln.Report, error) {
+	current, err := modulesAt(repo, head, dir)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	previous := vuln.Modules{}
+	if base != "" {
+		found, err := modulesAt(repo, base, dir)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			previous = found
+		}
+	}
+	return db.Check(previous, current), nil
+}
+
+// modulesAt reads the dependencies of the Go module at the given commit, looking in the component's build context
+// before the root of the repository. If neither has a go.mod, nil is returned.
+func modulesAt(repo git.Repo, ref, dir string) (vuln.Modules, error) {
+	dirs := []string{"."}
+	if dir != "" && path.Clean(dir) != "." {
+		dirs = []string{dir, "."}
+	}
+	for _, d := range dirs {
+		modules, err := vuln.ModulesAt(repo, ref, d)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		return modules, err
+	}
+	return nil, nil
+}
+
+// getJiraIssues looks up the issues referenced by all components, setting the issues of each component. The issues of
+// all components are returned, sorted by key.
+func getJiraIssues(components []*ComponentChanges) ([]*jira.Issue, error) {
+	var keys []string
+	for _, component := range components {
+		keys = append(keys, component.issueKeys...)
+	}
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+	found, missing, err := jira.GetIssues(keys)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range missing {
+		fmt.Fprintf(os.Stderr, "WARNING: issue %s does not exist or is not visible, skipping\n", key)
+	}
+	byKey := make(map[string]*jira.Issue, len(found))
+	var allIssues []*jira.Issue
+	for i := range found {
+		byKey[found[i].Key] = &found[i]
+		allIssues = append(allIssues, &found[i])
+	}
+	for _, component := range components {
+		component.Issues = nil
+		for _, key := range component.issueKeys {
+			if issue, ok := byKey[key]; ok {
+				component.Issues = append(component.Issues, issue)
+			}
+		}
+	}
+	slices.SortFunc(allIssues, func(a, b *jira.Issue) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return allIssues, nil
+}
+
+// uniqueCommits returns the commits of all components, without the duplicates of components sharing a repository
+func uniqueCommits(components []*ComponentChanges, commits func(*ComponentChanges) []git.Commit) []git.Commit {
+	seen := make(map[string]bool)
+	var unique []git.Commit
+	for _, component := range components {
+		for _, commit := range commits(component) {
+			if !seen[commit.SHA] {
+				seen[commit.SHA] = true
+				unique = append(unique, commit)
+			}
+		}
+	}
+	return unique
+}
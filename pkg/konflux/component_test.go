@@ -0,0 +1,84 @@
+package konflux
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/sebsoto/gojira/pkg/git"
+	"github.com/sebsoto/gojira/pkg/jira"
+)
+
+func TestNewReleaseComponents(t *testing.T) {
+	newIssue := func(key, summary string) *jira.Issue {
+		issue := &jira.Issue{Key: key}
+		issue.Fields.Summary = summary
+		return issue
+	}
+	shared := newIssue("OCPBUGS-1", "CVE-2024-1234 golang: net/http: memory exhaustion")
+	operatorOnly := newIssue("WINC-2", "Support a new Windows version")
+	bundleOnly := newIssue("OCPBUGS-3", "CVE-2024-5678 bundle vulnerability")
+	merge := func(sha string) git.Commit { return git.Commit{SHA: sha, Parents: []string{"a", "b"}} }
+	components := []*ComponentChanges{
+		{
+			Name:          "operator",
+			Merges:        []git.Commit{merge("aaa"), merge("bbb")},
+			MissingMerges: []git.Commit{merge("ccc")},
+			Issues:        []*jira.Issue{shared, operatorOnly},
+		},
+		{
+			Name:          "bundle",
+			Merges:        []git.Commit{merge("bbb"), merge("ddd")},
+			MissingMerges: []git.Commit{merge("ccc")},
+			Issues:        []*jira.Issue{shared, bundleOnly},
+		},
+	}
+
+	class := classifyRelease(components, nil)
+	raw, err := buildReleaseData([]*jira.Issue{shared, bundleOnly, operatorOnly}, class, notesSettings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newRelease("plan", "snap", raw)
+	var data releaseData
+	if err = json.Unmarshal(r.Spec.Data.Raw, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.ReleaseNotes.Type != "RHSA" {
+		t.Errorf("expected RHSA, got %s", data.ReleaseNotes.Type)
+	}
+	expectedCVEs := []cve{
+		{Component: "operator", Key: "CVE-2024-1234"},
+		{Component: "bundle", Key: "CVE-2024-1234"},
+		{Component: "bundle", Key: "CVE-2024-5678"},
+	}
+	if !slices.Equal(data.ReleaseNotes.CVEs, expectedCVEs) {
+		t.Errorf("expected CVEs %v, got %v", expectedCVEs, data.ReleaseNotes.CVEs)
+	}
+	if len(data.ReleaseNotes.Issues.Fixed) != 3 {
+		t.Errorf("expected 3 fixed issues, got %v", data.ReleaseNotes.Issues.Fixed)
+	}
+
+	merges := uniqueCommits(components, func(c *ComponentChanges) []git.Commit { return c.Merges })
+	var shas []string
+	for _, commit := range merges {
+		shas = append(shas, commit.SHA)
+	}
+	if !slices.Equal(shas, []string{"aaa", "bbb", "ddd"}) {
+		t.Errorf("unexpected merges %v", shas)
+	}
+	if missing := uniqueCommits(components, func(c *ComponentChanges) []git.Commit { return c.MissingMerges }); len(missing) != 1 {
+		t.Errorf("expected shared missing merge to be listed once, got %v", missing)
+	}
+}
+
+func TestTicketRegex(t *testing.T) {
+	ticketRe, err := ticketRegex([]string{"WINC", "OCPBUGS"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := "WINC-12: fix OCPBUGS-3 and OCPBUGS-\nnot MYWINC-4 or WINC-5a"
+	if keys := ticketRe.FindAllString(message, -1); !slices.Equal(keys, []string{"WINC-12", "OCPBUGS-3"}) {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+}
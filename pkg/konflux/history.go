@@ -0,0 +1,268 @@
+package konflux
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	applicationv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releasev1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sebsoto/gojira/pkg/git"
+)
+
+const (
+	// releasedCondition is the condition of a Release describing whether it finished successfully
+	releasedCondition = "Released"
+	// ResultSucceeded is the result of a Release which finished successfully
+	ResultSucceeded = "Succeeded"
+)
+
+// HistoryEntry describes a past Konflux Release
+type HistoryEntry struct {
+	Name        string    `json:"name"`
+	ReleasePlan string    `json:"releasePlan"`
+	Created     time.Time `json:"created"`
+	Snapshot    string    `json:"snapshot"`
+	// GitURL and Commit identify the source of the component whose build created the snapshot. They are empty if the
+	// snapshot no longer exists.
+	GitURL string `json:"gitURL"`
+	Commit string `json:"commit"`
+	// Versions are the tags pointing at the commit
+	Versions   []string           `json:"versions"`
+	Conditions []metav1.Condition `json:"conditions"`
+	// Issues are the Jira issues listed in the release notes of the Release
+	Issues []string `json:"issues"`
+	// Application and Images are empty if the snapshot no longer exists
+	Application string           `json:"application"`
+	Images      []ComponentImage `json:"images"`
+	// Advisory is the link to the advisory published by the Release, if its pipeline reported one
+	Advisory string `json:"advisory,omitempty"`
+}
+
+// ComponentImage is the image of a component released
+type ComponentImage struct {
+	Component string `json:"component"`
+	// Image is the pull spec of the image, by digest
+	Image string `json:"image"`
+}
+
+// releaseArtifacts holds the artifacts reported by release pipelines which are used
+type releaseArtifacts struct {
+	Advisory struct {
+		URL string `json:"url"`
+	} `json:"advisory"`
+}
+
+// Result summarizes the Released condition of the Release
+func (e *HistoryEntry) Result() string {
+	released := meta.FindStatusCondition(e.Conditions, releasedCondition)
+	switch {
+	case released == nil:
+		return "Unknown"
+	case released.Status == metav1.ConditionTrue:
+		return ResultSucceeded
+	}
+	return released.Reason
+}
+
+// ReleaseHistory returns the Releases of the given application, most recent first and grouped by ReleasePlan. If
+// releasePlans is given, only Releases of those plans are returned, and the application may be left empty to use the
+// application of the first plan.
+func ReleaseHistory(ctx context.Context, namespace, application string, releasePlans []string) ([]HistoryEntry, error) {
+	c, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	if application == "" {
+		if len(releasePlans) == 0 {
+			return nil, fmt.Errorf("an application or releaseplan must be given")
+		}
+		var rp releasev1alpha1.ReleasePlan
+		err = c.Get(ctx, types.NamespacedName{Name: releasePlans[0], Namespace: namespace}, &rp)
+		if err != nil {
+			return nil, err
+		}
+		application = rp.Spec.Application
+	}
+	var relList releasev1alpha1.ReleaseList
+	err = c.List(ctx, &relList, client.MatchingLabels{"appstudio.openshift.io/application": application},
+		client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	var history []HistoryEntry
+	tags := make(map[string][]git.Tag)
+	for i := range relList.Items {
+		rel := &relList.Items[i]
+		if len(releasePlans) > 0 && !slices.Contains(releasePlans, rel.Spec.ReleasePlan) {
+			continue
+		}
+		var snap applicationv1alpha1.Snapshot
+		err = c.Get(ctx, types.NamespacedName{Name: rel.Spec.Snapshot, Namespace: namespace}, &snap)
+		if apierrors.IsNotFound(err) {
+			fmt.Fprintf(os.Stderr, "WARNING: snapshot %s of release %s no longer exists\n", rel.Spec.Snapshot,
+				rel.GetName())
+		} else if err != nil {
+			return nil, err
+		}
+		entry := newHistoryEntry(rel, &snap)
+		if entry.GitURL != "" {
+			repoTags, ok := tags[entry.GitURL]
+			if !ok {
+				repoTags, err = getTags(entry.GitURL)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "WARNING: unable to list tags of %s: %s\n", entry.GitURL, err)
+				}
+				tags[entry.GitURL] = repoTags
+			}
+			entry.Versions = tagsAt(repoTags, entry.Commit)
+		}
+		history = append(history, entry)
+	}
+	slices.SortStableFunc(history, func(a, b HistoryEntry) int {
+		if c := cmp.Compare(a.ReleasePlan, b.ReleasePlan); c != 0 {
+			return c
+		}
+		return b.Created.Compare(a.Created)
+	})
+	return history, nil
+}
+
+// GetRelease describes the named Release. The tags at its commit are not looked up.
+func GetRelease(ctx context.Context, namespace, name string) (*HistoryEntry, error) {
+	c, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	var rel releasev1alpha1.Release
+	if err = c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &rel); err != nil {
+		return nil, err
+	}
+	var snap applicationv1alpha1.Snapshot
+	err = c.Get(ctx, types.NamespacedName{Name: rel.Spec.Snapshot, Namespace: namespace}, &snap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	entry := newHistoryEntry(&rel, &snap)
+	return &entry, nil
+}
+
+// newHistoryEntry describes the given Release of the given snapshot. The snapshot may be empty if it does not exist.
+func newHistoryEntry(rel *releasev1alpha1.Release, snap *applicationv1alpha1.Snapshot) HistoryEntry {
+	entry := HistoryEntry{
+		Name:        rel.GetName(),
+		ReleasePlan: rel.Spec.ReleasePlan,
+		Created:     rel.GetCreationTimestamp().Time,
+		Snapshot:    rel.Spec.Snapshot,
+		Versions:    []string{},
+		Conditions:  append([]metav1.Condition{}, rel.Status.Conditions...),
+		Issues:      []string{},
+		Application: snap.Spec.Application,
+		Images:      []ComponentImage{},
+	}
+	entry.GitURL, entry.Commit = snapshotSource(snap)
+	for _, component := range snap.Spec.Components {
+		entry.Images = append(entry.Images, ComponentImage{Component: component.Name, Image: component.ContainerImage})
+	}
+	if rel.Status.Artifacts != nil {
+		var artifacts releaseArtifacts
+		if err := json.Unmarshal(rel.Status.Artifacts.Raw, &artifacts); err == nil {
+			entry.Advisory = artifacts.Advisory.URL
+		}
+	}
+	if rel.Spec.Data != nil {
+		var data releaseData
+		if err := json.Unmarshal(rel.Spec.Data.Raw, &data); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: unable to parse release notes of release %s: %s\n", rel.GetName(), err)
+		}
+		for _, fixed := range data.ReleaseNotes.Issues.Fixed {
+			entry.Issues = append(entry.Issues, fixed.ID)
+		}
+	}
+	return entry
+}
+
+// snapshotSource returns the repository and commit of the component whose build created the snapshot, or of its first
+// component if it was not created by a build
+func snapshotSource(snap *applicationv1alpha1.Snapshot) (string, string) {
+	var gitURL, commit string
+	for _, component := range snap.Spec.Components {
+		if component.Source.GitSource == nil {
+			continue
+		}
+		if component.Name == snap.Labels[componentLabel] {
+			return component.Source.GitSource.URL, component.Source.GitSource.Revision
+		}
+		if gitURL == "" {
+			gitURL, commit = component.Source.GitSource.URL, component.Source.GitSource.Revision
+		}
+	}
+	return gitURL, commit
+}
+
+// snapshotURLs returns the distinct git URLs the components of the snapshot are built from
+func snapshotURLs(snap *applicationv1alpha1.Snapshot) []string {
+	var urls []string
+	for _, component := range snap.Spec.Components {
+		if component.Source.GitSource != nil && !slices.Contains(urls, component.Source.GitSource.URL) {
+			urls = append(urls, component.Source.GitSource.URL)
+		}
+	}
+	return urls
+}
+
+func getTags(gitURL string) ([]git.Tag, error) {
+	repo, err := git.NewRepo(gitURL)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetTags()
+}
+
+// tagsAt returns the names of the tags pointing at the given commit
+func tagsAt(tags []git.Tag, commit string) []string {
+	names := []string{}
+	for _, tag := range tags {
+		if tag.Sha == commit {
+			names = append(names, tag.Name)
+		}
+	}
+	return names
+}
+
+// ReleasesFixing returns, for each ReleasePlan, the first Release listing the given issue in its release notes.
+// Successful Releases are preferred over ones which failed or are still in progress.
+func ReleasesFixing(history []HistoryEntry, key string) []HistoryEntry {
+	first := make(map[string]HistoryEntry)
+	var plans []string
+	for _, entry := range history {
+		if !slices.Contains(entry.Issues, key) {
+			continue
+		}
+		current, found := first[entry.ReleasePlan]
+		if !found {
+			plans = append(plans, entry.ReleasePlan)
+		}
+		succeeded, currentSucceeded := entry.Result() == ResultSucceeded, current.Result() == ResultSucceeded
+		if !found || (succeeded && !currentSucceeded) ||
+			(succeeded == currentSucceeded && entry.Created.Before(current.Created)) {
+			first[entry.ReleasePlan] = entry
+		}
+	}
+	var releases []HistoryEntry
+	for _, plan := range plans {
+		releases = append(releases, first[plan])
+	}
+	return releases
+}
@@ -0,0 +1,91 @@
+package konflux
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	applicationv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releasev1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/sebsoto/gojira/pkg/git"
+)
+
+func TestNewHistoryEntry(t *testing.T) {
+	rel := &releasev1alpha1.Release{
+		ObjectMeta: metav1.ObjectMeta{Name: "wmco-prod-abcde"},
+		Spec: releasev1alpha1.ReleaseSpec{
+			Snapshot:    "wmco-snap",
+			ReleasePlan: "wmco-prod",
+			Data: &runtime.RawExtension{Raw: []byte(`{"releaseNotes":{"type":"RHBA","issues":{"fixed":` +
+				`[{"id":"OCPBUGS-1","source":"issues.redhat.com"},{"id":"WINC-2","source":"issues.redhat.com"}]}}}`)},
+		},
+	}
+	rel.Status.Conditions = []metav1.Condition{{Type: releasedCondition, Status: metav1.ConditionFalse, Reason: "Failed"}}
+	rel.Status.Artifacts = &runtime.RawExtension{Raw: []byte(`{"advisory":{"url":"https://errata.example.com/1"}}`)}
+	snap := &applicationv1alpha1.Snapshot{ObjectMeta: metav1.ObjectMeta{
+		Labels: map[string]string{componentLabel: "operator"},
+	}}
+	gitSource := func(url, revision string) applicationv1alpha1.ComponentSource {
+		return applicationv1alpha1.ComponentSource{ComponentSourceUnion: applicationv1alpha1.ComponentSourceUnion{
+			GitSource: &applicationv1alpha1.GitSource{URL: url, Revision: revision},
+		}}
+	}
+	snap.Spec.Components = []applicationv1alpha1.SnapshotComponent{
+		{Name: "bundle", Source: gitSource("https://github.com/org/bundle", "bbb")},
+		{Name: "operator", Source: gitSource("https://github.com/org/operator", "aaa"), ContainerImage: "quay.io/org/operator@sha256:1"},
+	}
+
+	entry := newHistoryEntry(rel, snap)
+	if entry.GitURL != "https://github.com/org/operator" || entry.Commit != "aaa" {
+		t.Errorf("expected the labelled component's source, got %s %s", entry.GitURL, entry.Commit)
+	}
+	if !slices.Equal(entry.Issues, []string{"OCPBUGS-1", "WINC-2"}) {
+		t.Errorf("unexpected issues %v", entry.Issues)
+	}
+	if len(entry.Images) != 2 || entry.Images[1] != (ComponentImage{Component: "operator", Image: "quay.io/org/operator@sha256:1"}) {
+		t.Errorf("unexpected images %v", entry.Images)
+	}
+	if entry.Advisory != "https://errata.example.com/1" {
+		t.Errorf("unexpected advisory %s", entry.Advisory)
+	}
+	if entry.Result() != "Failed" {
+		t.Errorf("unexpected result %s", entry.Result())
+	}
+
+	missing := newHistoryEntry(rel, &applicationv1alpha1.Snapshot{})
+	if missing.Commit != "" || missing.GitURL != "" {
+		t.Errorf("expected no source for a missing snapshot, got %s %s", missing.GitURL, missing.Commit)
+	}
+
+	tags := []git.Tag{{Name: "v10.19.0", Sha: "aaa"}, {Name: "v10.18.0", Sha: "ccc"}, {Name: "latest", Sha: "aaa"}}
+	if versions := tagsAt(tags, "aaa"); !slices.Equal(versions, []string{"v10.19.0", "latest"}) {
+		t.Errorf("unexpected versions %v", versions)
+	}
+}
+
+func TestReleasesFixing(t *testing.T) {
+	succeeded := []metav1.Condition{{Type: releasedCondition, Status: metav1.ConditionTrue}}
+	failed := []metav1.Condition{{Type: releasedCondition, Status: metav1.ConditionFalse, Reason: "Failed"}}
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC) }
+	history := []HistoryEntry{
+		{Name: "prod-3", ReleasePlan: "prod", Created: day(3), Conditions: succeeded, Issues: []string{"WINC-1"}},
+		{Name: "prod-2", ReleasePlan: "prod", Created: day(2), Conditions: succeeded, Issues: []string{"WINC-1"}},
+		{Name: "prod-1", ReleasePlan: "prod", Created: day(1), Conditions: failed, Issues: []string{"WINC-1"}},
+		{Name: "stage-2", ReleasePlan: "stage", Created: day(2), Conditions: failed, Issues: []string{"WINC-1"}},
+		{Name: "stage-1", ReleasePlan: "stage", Created: day(1), Conditions: succeeded, Issues: []string{"WINC-2"}},
+	}
+	releases := ReleasesFixing(history, "WINC-1")
+	var names []string
+	for _, release := range releases {
+		names = append(names, release.Name)
+	}
+	if !slices.Equal(names, []string{"prod-2", "stage-2"}) {
+		t.Errorf("unexpected releases %v", names)
+	}
+	if releases := ReleasesFixing(history, "WINC-3"); len(releases) != 0 {
+		t.Errorf("expected no releases, got %v", releases)
+	}
+}
@@ -2,14 +2,15 @@ package konflux
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"regexp"
 	"slices"
-	"strconv"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 
 	applicationv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
 	releasev1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
@@ -20,19 +21,37 @@ import (
 	clientconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/yaml"
 
+	"github.com/sebsoto/gojira/pkg/config"
 	"github.com/sebsoto/gojira/pkg/git"
 	"github.com/sebsoto/gojira/pkg/jira"
 	"github.com/sebsoto/gojira/pkg/semver"
+	"github.com/sebsoto/gojira/pkg/vuln"
 )
 
 // Release contains all information required to describe an upcoming release
 type Release struct {
 	*releasev1alpha1.Release
+	// Components holds the changes to each component of the snapshot
+	Components []*ComponentChanges
+	// MissingMerges, Merges and Issues are aggregated from all components
 	MissingMerges []git.Commit
 	Merges        []git.Commit
 	Issues        []*jira.Issue
 	*applicationv1alpha1.Snapshot
 	Sha string
+	// Provenance describes how the snapshot was selected
+	Provenance string
+	// TestStatus is the integration test status of the snapshot
+	TestStatus *TestStatus
+	// Type is the advisory type of the release, and TypeReasons explain why it was chosen
+	Type        string
+	TypeReasons []string
+	// Application is the name of the application being released
+	Application string
+	// Environment is whether the ReleasePlan is the product's production or stage plan, and empty if it is neither or
+	// no product was given
+	Environment string
+	client      client.Client
 }
 
 type releaseData struct {
@@ -40,9 +59,19 @@ type releaseData struct {
 }
 
 type releaseNotes struct {
-	Type   string `json:"type"`
-	CVEs   []cve  `json:"cves"`
-	Issues issues `json:"issues"`
+	Type           string   `json:"type"`
+	Synopsis       string   `json:"synopsis,omitempty"`
+	Topic          string   `json:"topic,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	Solution       string   `json:"solution,omitempty"`
+	References     []string `json:"references,omitempty"`
+	ProductID      []int    `json:"product_id,omitempty"`
+	ProductName    string   `json:"product_name,omitempty"`
+	ProductVersion string   `json:"product_version,omitempty"`
+	ProductStream  string   `json:"product_stream,omitempty"`
+	CPE            string   `json:"cpe,omitempty"`
+	CVEs           []cve    `json:"cves"`
+	Issues         issues   `json:"issues"`
 }
 type cve struct {
 	Component string `json:"component"`
@@ -57,7 +86,37 @@ type issue struct {
 	Source string `json:"source"`
 }
 
-func NewRelease(namespace, releaseplan, version string, jiraProjects []string, baseCommitOverride string) (*Release, error) {
+// ReleaseOptions are optional settings used when generating a release
+type ReleaseOptions struct {
+	// BaseCommitOverride is the commit to list changes from, instead of the previous release
+	BaseCommitOverride string
+	// BranchStrategy is used to find the branch of the previous release. If nil, release-4.N branches are assumed.
+	BranchStrategy *git.BranchStrategy
+	// Snapshot selects the snapshot to release. By default the snapshot of the latest successful release is used.
+	Snapshot SnapshotSelector
+	// Product describes the released product in the release notes. If nil, the release notes only list the type,
+	// CVEs and fixed issues.
+	Product *config.Product
+	// NotesFragment is YAML merged into the data of the Release, taking precedence over generated values
+	NotesFragment []byte
+	// VulnDB is used to check the Go dependencies of each component for vulnerabilities fixed or introduced since the
+	// previous release. If nil, dependencies are not checked.
+	VulnDB *vuln.Database
+	// MergesOnly limits the listed commits to merge commits. By default every commit is listed, so that changes merged
+	// by squashing or rebasing are included.
+	MergesOnly bool
+}
+
+// commitFilter returns the filter commits of the components are listed with
+func (o ReleaseOptions) commitFilter() git.FilterFunction {
+	if o.MergesOnly {
+		return git.IsMerge
+	}
+	return git.AllCommits
+}
+
+// newClient returns a client for the cluster in the current kubeconfig context, able to use Konflux types
+func newClient() (client.Client, error) {
 	config, err := clientconfig.GetConfig()
 	if err != nil {
 		return nil, err
@@ -68,100 +127,155 @@ func NewRelease(namespace, releaseplan, version string, jiraProjects []string, b
 	}
 	releasev1alpha1.AddToScheme(c.Scheme())
 	applicationv1alpha1.AddToScheme(c.Scheme())
-	var rp releasev1alpha1.ReleasePlan
-	err = c.Get(context.Background(), types.NamespacedName{Name: releaseplan, Namespace: namespace}, &rp)
-	if err != nil {
-		return nil, err
-	}
-	var relList releasev1alpha1.ReleaseList
-	err = c.List(context.Background(), &relList, client.MatchingLabels{"appstudio.openshift.io/application": rp.Spec.Application}, client.InNamespace(rp.GetNamespace()))
-	if err != nil {
-		return nil, err
-	}
-	lastRelease, err := latestRelease(relList.Items)
+	return c, nil
+}
+
+func NewRelease(namespace, releaseplan, version string, jiraProjects []string, opts ReleaseOptions) (*Release, error) {
+	c, err := newClient()
 	if err != nil {
 		return nil, err
 	}
-	var snap applicationv1alpha1.Snapshot
-	err = c.Get(context.Background(), types.NamespacedName{Name: lastRelease.Spec.Snapshot, Namespace: namespace}, &snap)
+	var rp releasev1alpha1.ReleasePlan
+	err = c.Get(context.Background(), types.NamespacedName{Name: releaseplan, Namespace: namespace}, &rp)
 	if err != nil {
 		return nil, err
 	}
-	var gitURL, snapshotCommit string
-	componentName := snap.Labels["appstudio.openshift.io/component"]
-	for _, component := range snap.Spec.Components {
-		if component.Name == componentName {
-			gitURL = component.Source.GitSource.URL
-			snapshotCommit = component.Source.GitSource.Revision
-		}
-	}
-	branch := snap.Annotations["build.appstudio.redhat.com/target_branch"]
-	var component applicationv1alpha1.Component
-	err = c.Get(context.Background(), types.NamespacedName{Name: componentName, Namespace: namespace}, &component)
+	snap, provenance, err := selectSnapshot(context.Background(), c, rp.GetNamespace(), rp.Spec.Application, opts.Snapshot)
 	if err != nil {
 		return nil, err
 	}
-
-	repo, err := git.NewRepo(gitURL)
+	testStatus, err := snapshotTestStatus(snap)
 	if err != nil {
 		return nil, err
 	}
-
-	mergesSinceSnapshot, err := repo.ListCommits(component.Spec.Source.GitSource.Revision, snapshotCommit, git.IsMerge)
+	versionSemver, err := semver.New(version)
 	if err != nil {
 		return nil, err
 	}
-
-	versionSemver, err := semver.New(version)
+	ticketRe, err := ticketRegex(jiraProjects)
 	if err != nil {
 		return nil, err
 	}
-	commits := []git.Commit{}
-	if baseCommitOverride != "" {
-		commits, err = repo.ListCommits(snapshotCommit, baseCommitOverride, git.IsMerge)
-		if err != nil {
-			return nil, err
+	if opts.BranchStrategy == nil {
+		opts.BranchStrategy = git.DefaultBranchStrategy()
+	}
+	// The snapshot is identified by the commit of the component whose build created it, and the base commit override
+	// only applies to components built from its repository
+	primaryURL, snapshotCommit := snapshotSource(snap)
+	// A local checkout stands in for a single repository, and would be used for every component
+	if urls := snapshotURLs(snap); git.DefaultRepoOptions.LocalPath != "" && len(urls) > 1 {
+		return nil, fmt.Errorf("a local git path cannot be used for snapshot %s, its components are built from %d "+
+			"repositories: %s", snap.GetName(), len(urls), strings.Join(urls, ", "))
+	}
+	var components []*ComponentChanges
+	repos := make(map[string]git.Repo)
+	for _, snapComponent := range snap.Spec.Components {
+		if snapComponent.Source.GitSource == nil {
+			fmt.Fprintf(os.Stderr, "WARNING: component %s has no git source, skipping\n", snapComponent.Name)
+			continue
+		}
+		componentOpts := opts
+		if snapComponent.Source.GitSource.URL != primaryURL {
+			componentOpts.BaseCommitOverride = ""
 		}
-	} else {
-		commits, err = commitsSinceLastRelease(repo, *versionSemver, snapshotCommit, branch)
+		changes, err := componentChanges(context.Background(), c, snap, snapComponent, *versionSemver, ticketRe,
+			componentOpts, repos)
 		if err != nil {
 			return nil, err
 		}
-
+		components = append(components, changes)
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("snapshot %s has no components built from git", snap.GetName())
 	}
-	jiraTickets, err := getJiraIssues(jiraProjects, commits)
+	jiraTickets, err := getJiraIssues(components)
 	if err != nil {
 		return nil, err
 	}
 
-	r, err := newRelease(componentName, jiraTickets, rp.GetName(), snap.GetName())
+	var cveFields []string
+	if opts.Product != nil {
+		cveFields = opts.Product.ReleaseNotes.CVEFields
+	}
+	class := classifyRelease(components, cveFields)
+	data, err := buildReleaseData(jiraTickets, class, notesSettings{
+		version:  version,
+		product:  opts.Product,
+		fragment: opts.NotesFragment,
+	})
 	if err != nil {
 		return nil, err
 	}
+	if class, err = class.withDataType(data); err != nil {
+		return nil, err
+	}
+	r := newRelease(rp.GetName(), snap.GetName(), data)
 
 	release := &Release{
 		Release:       r,
-		MissingMerges: mergesSinceSnapshot,
-		Merges:        commits,
+		Components:    components,
+		MissingMerges: uniqueCommits(components, func(c *ComponentChanges) []git.Commit { return c.MissingMerges }),
+		Merges:        uniqueCommits(components, func(c *ComponentChanges) []git.Commit { return c.Merges }),
 		Issues:        jiraTickets,
 		Sha:           snapshotCommit,
-		Snapshot:      &snap,
+		Snapshot:      snap,
+		Provenance:    provenance,
+		TestStatus:    testStatus,
+		Type:          class.Type,
+		TypeReasons:   class.Reasons,
+		Application:   rp.Spec.Application,
+		Environment:   planEnvironment(opts.Product, rp.GetName()),
+		client:        c,
 	}
 
 	return release, nil
 }
 
 func (r *Release) PrintContents() {
+	fmt.Printf("Snapshot: %s (%s)\n", r.Snapshot.GetName(), r.Provenance)
 	fmt.Printf("Snapshot timestamp: %v\n", r.Snapshot.GetCreationTimestamp())
 	fmt.Printf("Snapshot commit: %v\n", r.Sha)
 	fmt.Printf("-----\n\n")
+	fmt.Printf("Integration tests: %s\n", r.TestStatus.Summary())
+	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "Scenario\tStatus\tDetails")
+	fmt.Fprintln(w, "___\t___\t___")
+	for _, scenario := range r.TestStatus.Scenarios {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", scenario.Scenario, scenario.Status, scenario.Details)
+	}
+	w.Flush()
+	fmt.Printf("-----\n\n")
+	fmt.Printf("Components:\n")
+	w = tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "Component\tCommit\tMerges\tMissing Merges\tIssues")
+	fmt.Fprintln(w, "___\t___\t___\t___\t___")
+	for _, component := range r.Components {
+		var keys []string
+		for _, ticket := range component.Issues {
+			keys = append(keys, ticket.Key)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", component.Name, component.Sha, len(component.Merges),
+			len(component.MissingMerges), strings.Join(keys, ", "))
+	}
+	w.Flush()
+	fmt.Printf("-----\n\n")
 	fmt.Printf("%d recent merges not included in release:\n", len(r.MissingMerges))
 	for i, mergeCommit := range r.MissingMerges {
 		fmt.Printf("%d: %s\n", i+1, mergeCommit.Message)
 	}
 	fmt.Printf("-----\n\n")
+	for _, component := range r.Components {
+		if component.Dependencies != nil {
+			printDependencies(component.Name, component.Dependencies)
+		}
+	}
+	fmt.Printf("Release type: %s\n", r.Type)
+	for _, reason := range r.TypeReasons {
+		fmt.Printf("  %s\n", reason)
+	}
+	fmt.Printf("-----\n\n")
 	fmt.Printf("Jira issues included in this release:\n")
-	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	w = tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
 	fmt.Fprintln(w, "Issue\tSummary\tFix Version")
 	fmt.Fprintln(w, "___\t___\t___")
 	for _, ticket := range r.Issues {
@@ -172,6 +286,29 @@ func (r *Release) PrintContents() {
 
 }
 
+// printDependencies prints the dependency changes of a component, and the vulnerabilities they affect
+func printDependencies(component string, report *vuln.Report) {
+	changes := report.Changes
+	fmt.Printf("Go dependency changes in component %s:\n", component)
+	for _, change := range slices.Concat(changes.Changed, changes.Added, changes.Removed) {
+		fmt.Printf("  %s\n", change)
+	}
+	for _, section := range []struct {
+		name  string
+		vulns []vuln.Vulnerability
+	}{
+		{"fixed", report.Fixed},
+		{"introduced", report.Introduced},
+		{"remaining", report.Remaining},
+	} {
+		fmt.Printf("%d vulnerabilities %s:\n", len(section.vulns), section.name)
+		for _, v := range section.vulns {
+			fmt.Printf("  %s %v in %s %s: %s\n", v.ID, v.CVEs, v.Module, v.Version, v.Summary)
+		}
+	}
+	fmt.Printf("-----\n\n")
+}
+
 func (r *Release) ReleaseYAML() string {
 	yamlNotes, err := yaml.Marshal(r.Release)
 	if err != nil {
@@ -182,11 +319,54 @@ func (r *Release) ReleaseYAML() string {
 	return strings.Split(string(yamlNotes), "\nstatus:\n")[0]
 }
 
+// Create creates the Release in the namespace of the snapshot. Once created, the Release's name is set to the name
+// generated for it by the cluster.
+func (r *Release) Create(ctx context.Context) error {
+	r.Release.Namespace = r.Snapshot.GetNamespace()
+	return r.client.Create(ctx, r.Release)
+}
+
+// releaseURLData is passed to the template of a Release URL
+type releaseURLData struct {
+	Name        string
+	Namespace   string
+	Application string
+}
+
+// URL returns a link to the created Release, see ReleaseURL
+func (r *Release) URL(urlTemplate string) (string, error) {
+	return ReleaseURL(urlTemplate, r.Release.GetNamespace(), r.Release.GetName(), r.Application)
+}
+
+// ReleaseURL returns a link to the named Release, rendered from the given template which is passed the Name, Namespace
+// and Application of the release. If urlTemplate is empty, the Release's URL in the cluster API is returned.
+func ReleaseURL(urlTemplate, namespace, name, application string) (string, error) {
+	if urlTemplate == "" {
+		config, err := clientconfig.GetConfig()
+		if err != nil {
+			return "", err
+		}
+		return url.JoinPath(config.Host, "apis", releasev1alpha1.GroupVersion.Group, releasev1alpha1.GroupVersion.Version,
+			"namespaces", namespace, "releases", name)
+	}
+	t, err := template.New("url").Parse(urlTemplate)
+	if err != nil {
+		return "", err
+	}
+	out := new(strings.Builder)
+	err = t.Execute(out, releaseURLData{
+		Name:        name,
+		Namespace:   namespace,
+		Application: application,
+	})
+	return out.String(), err
+}
+
 func latestRelease(relList []releasev1alpha1.Release) (*releasev1alpha1.Release, error) {
 	// grab recent release
 	successfulReleases := slices.DeleteFunc(relList, func(a releasev1alpha1.Release) bool {
 		for _, condition := range a.Status.Conditions {
-			if condition.Type == "Released" && condition.Status == "True" {
+			if condition.Type == releasedCondition && condition.Status == "True" {
 				return false
 			}
 		}
@@ -201,40 +381,8 @@ func latestRelease(relList []releasev1alpha1.Release) (*releasev1alpha1.Release,
 	return &latestRelease, nil
 }
 
-func newRelease(component string, jiraIssues []*jira.Issue, releaseplan, snapshot string) (*releasev1alpha1.Release, error) {
-	var cveNames []string
-	for _, jiraIssue := range jiraIssues {
-		if cve := getCVEName(jiraIssue.Fields.Summary); cve != "" {
-			cveNames = append(cveNames, cve)
-		}
-	}
-
-	releaseType := "RHBA"
-	cves := []cve{}
-	if len(cveNames) != 0 {
-		releaseType = "RHSA"
-		for _, cveName := range cveNames {
-			cves = append(cves, cve{Component: component, Key: cveName})
-		}
-	}
-	var fixedIssues []issue
-	for _, jiraIssue := range jiraIssues {
-		fixedIssues = append(fixedIssues, issue{
-			ID:     jiraIssue.Key,
-			Source: "issues.redhat.com",
-		})
-	}
-	releaseNotes := releaseData{
-		ReleaseNotes: releaseNotes{
-			Type:   releaseType,
-			CVEs:   cves,
-			Issues: issues{Fixed: fixedIssues},
-		},
-	}
-	data, err := json.Marshal(releaseNotes)
-	if err != nil {
-		return nil, err
-	}
+// newRelease returns a Release of the snapshot with the given data
+func newRelease(releaseplan, snapshot string, data []byte) *releasev1alpha1.Release {
 	return &releasev1alpha1.Release{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Release",
@@ -250,100 +398,61 @@ func newRelease(component string, jiraIssues []*jira.Issue, releaseplan, snapsho
 				Raw: data,
 			},
 		},
-	}, nil
-
-}
-
-func getCVEName(summary string) string {
-	if strings.HasPrefix(summary, "CVE-") {
-		return strings.Split(summary, " ")[0]
 	}
-	return ""
 }
 
+// ticketRegex matches the keys of issues in the given projects, as whole words
 func ticketRegex(projects []string) (*regexp.Regexp, error) {
-	regex := fmt.Sprintf("%s-[0-9]*", projects[0])
+	regex := fmt.Sprintf(`\b%s-[0-9]+\b`, projects[0])
 	if len(projects) > 1 {
 		for _, project := range projects[1:] {
-			regex += fmt.Sprintf("|%s-[0-9]*", project)
+			regex += fmt.Sprintf(`|\b%s-[0-9]+\b`, project)
 		}
 	}
 	return regexp.Compile(regex)
 }
 
-func getJiraIssues(projects []string, commits []git.Commit) ([]*jira.Issue, error) {
-	re, err := ticketRegex(projects)
-	if err != nil {
-		return nil, err
-	}
-	var jiraIssues []string
-	for _, commit := range commits {
-		matches := re.FindAllString(commit.Message, -1)
-		for _, match := range matches {
-			jiraIssues = append(jiraIssues, match)
-		}
-	}
-
-	// Remove duplicate tickets
-	foundJiraTickets := make(map[string]*jira.Issue)
-	for _, ticket := range jiraIssues {
-		if _, found := foundJiraTickets[ticket]; !found {
-			jiraIssue, err := jira.GetIssue(ticket)
-			if err != nil {
-				return nil, err
-			}
-			foundJiraTickets[ticket] = jiraIssue
-		}
-	}
-	var uniqueTickets []*jira.Issue
-	for _, jiraTicket := range foundJiraTickets {
-		uniqueTickets = append(uniqueTickets, jiraTicket)
-	}
-	return uniqueTickets, nil
-}
-
 // commitsSinceLastRelease returns a list of commits from the given HEAD to either the last tagged release, or from the
-// branching point of the previous release branch, whichever is more recent.
-func commitsSinceLastRelease(repo git.Repo, releaseVersion semver.Semver, head string, branch string) ([]git.Commit, error) {
+// branching point of the previous release branch, whichever is more recent. The commit the list was cut at is also
+// returned, and is empty if there is neither a previous release nor a previous branch. Only commits matching the filter
+// are returned.
+func commitsSinceLastRelease(repo git.Repo, releaseVersion semver.Semver, head, branch string,
+	branchStrategy *git.BranchStrategy, filter git.FilterFunction) ([]git.Commit, string, error) {
 	var branchingPoint string
-	var err error
 	previousTag, err := git.FindPreviousTag(repo, releaseVersion)
-	if err != nil {
-		return nil, err
+	var noPreviousRelease *git.NoPreviousReleaseError
+	if errors.As(err, &noPreviousRelease) {
+		fmt.Fprintf(os.Stderr, "WARNING: %s, including all commits\n", err)
+	} else if err != nil {
+		return nil, "", err
 	}
 	listEnd := previousTag
-	if strings.HasPrefix(branch, "release-4.") {
-		prevBranch, err := decrementReleaseBranch(branch)
-		if err != nil {
-			return nil, err
-		}
-		listEnd, err = repo.MergeBase(prevBranch, head)
+	prevBranch, found, err := branchStrategy.PreviousBranch(branch)
+	if err != nil {
+		return nil, "", err
+	}
+	if found {
+		branchingPoint, err = repo.MergeBase(prevBranch, head)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		listEnd = branchingPoint
 	}
 
-	commits, err := repo.ListCommits(head, listEnd, git.IsMerge)
+	// Commits are filtered after the list is trimmed, as the release tag is not necessarily on a merge commit
+	commits, err := repo.ListCommits(head, listEnd, git.AllCommits)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	// Go through the commit list and remove all commits after the branching point or a previous release tag
 	for i, commit := range commits {
-		if commit.SHA == previousTag || commit.SHA == branchingPoint {
-			return commits[:i], nil
+		if (previousTag != "" && commit.SHA == previousTag) || (branchingPoint != "" && commit.SHA == branchingPoint) {
+			commits = commits[:i]
+			listEnd = commit.SHA
+			break
 		}
 	}
-	return commits, nil
-}
-
-func decrementReleaseBranch(branch string) (string, error) {
-	split := strings.Split(branch, "release-4.")
-	if len(split) != 2 {
-		return "", fmt.Errorf("unexpected branch format: %s", branch)
-	}
-	branchSuffix, err := strconv.Atoi(split[1])
-	if err != nil {
-		return "", err
-	}
-	return fmt.Sprintf("release-4.%d", branchSuffix-1), nil
+	return slices.DeleteFunc(commits, func(commit git.Commit) bool {
+		return !filter(commit)
+	}), listEnd, nil
 }
@@ -0,0 +1,91 @@
+package konflux
+
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+	"testing"
+
+	"github.com/sebsoto/gojira/pkg/git"
+	"github.com/sebsoto/gojira/pkg/semver"
+)
+
+// fakeRepo is a repository with a single line of history, newest commit first
+type fakeRepo struct {
+	history   []string
+	tags      []git.Tag
+	mergeBase string
+}
+
+func (r *fakeRepo) GetTags() ([]git.Tag, error) {
+	return r.tags, nil
+}
+
+func (r *fakeRepo) ListCommits(start, end string, filter git.FilterFunction) ([]git.Commit, error) {
+	var commits []git.Commit
+	listing := false
+	for _, sha := range r.history {
+		if sha == end {
+			break
+		}
+		listing = listing || sha == start
+		if listing && filter(git.Commit{SHA: sha}) {
+			commits = append(commits, git.Commit{SHA: sha})
+		}
+	}
+	return commits, nil
+}
+
+func (r *fakeRepo) MergeBase(string, string) (string, error) {
+	return r.mergeBase, nil
+}
+
+func (r *fakeRepo) ReadFile(ref, path string) ([]byte, error) {
+	return nil, fmt.Errorf("%s at %s: %w", path, ref, fs.ErrNotExist)
+}
+
+func TestCommitsSinceLastRelease(t *testing.T) {
+	version, err := semver.New("v10.19.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name     string
+		tags     []git.Tag
+		expected []string
+		cutAt    string
+	}{
+		{
+			name:     "previous tag after the branching point",
+			tags:     []git.Tag{{Name: "v10.19.0", Sha: "ccc"}, {Name: "v10.18.0", Sha: "old"}},
+			expected: []string{"eee", "ddd"},
+			cutAt:    "ccc",
+		},
+		{
+			name:     "no previous tag on the branch",
+			tags:     []git.Tag{{Name: "v10.18.0", Sha: "old"}},
+			expected: []string{"eee", "ddd", "ccc", "bbb"},
+			cutAt:    "aaa",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeRepo{history: []string{"eee", "ddd", "ccc", "bbb", "aaa"}, tags: tc.tags, mergeBase: "aaa"}
+			commits, cutAt, err := commitsSinceLastRelease(repo, *version, "eee", "release-4.19",
+				git.DefaultBranchStrategy(), git.AllCommits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var shas []string
+			for _, commit := range commits {
+				shas = append(shas, commit.SHA)
+			}
+			if !slices.Equal(shas, tc.expected) {
+				t.Errorf("expected commits %v, got %v", tc.expected, shas)
+			}
+			if cutAt != tc.cutAt {
+				t.Errorf("expected the list to be cut at %s, got %s", tc.cutAt, cutAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package konflux
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/pkg/jira"
+	"github.com/sebsoto/gojira/pkg/vuln"
+	"github.com/sebsoto/gojira/templates"
+)
+
+const (
+	typeSecurity    = "RHSA"
+	typeBugFix      = "RHBA"
+	typeEnhancement = "RHEA"
+)
+
+var (
+	// cveRegex matches CVE IDs anywhere in a string
+	cveRegex = regexp.MustCompile(`CVE-\d{4}-\d{4,}`)
+	// cveIDRegex matches a single CVE ID
+	cveIDRegex = regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`)
+)
+
+// cveReferenceURL is the link added to the release notes for each CVE fixed
+const cveReferenceURL = "https://access.redhat.com/security/cve/"
+
+// notesSettings are the inputs of the release notes other than the changes included in the release
+type notesSettings struct {
+	version string
+	// product describes the released product. If nil, only the type, CVEs and fixed issues are generated.
+	product *config.Product
+	// fragment is YAML merged into the Release's data, taking precedence over generated values
+	fragment []byte
+}
+
+// cveMatch is a CVE found in a Jira issue
+type cveMatch struct {
+	key string
+	// source is where in the issue the CVE was found
+	source string
+}
+
+// issueCVEs returns the CVEs referenced by the issue's labels, summary, and the given custom fields
+func issueCVEs(issue *jira.Issue, cveFields []string) []cveMatch {
+	var matches []cveMatch
+	add := func(source, text string) {
+		for _, key := range cveRegex.FindAllString(text, -1) {
+			if !slices.ContainsFunc(matches, func(m cveMatch) bool { return m.key == key }) {
+				matches = append(matches, cveMatch{key: key, source: source})
+			}
+		}
+	}
+	for _, label := range issue.Fields.Labels {
+		add("label", label)
+	}
+	add("summary", issue.Fields.Summary)
+	for _, field := range cveFields {
+		add(field, string(issue.Fields.Custom[field]))
+	}
+	return matches
+}
+
+// classification is the type of a release and the CVEs it fixes
+type classification struct {
+	Type string
+	CVEs []cve
+	// Reasons explain why the type was chosen
+	Reasons []string
+}
+
+// classifyRelease finds the CVEs fixed by the issues of each component, and by updates to its dependencies. A release
+// fixing CVEs is a security advisory, any other release a bug fix advisory.
+func classifyRelease(components []*ComponentChanges, cveFields []string) classification {
+	c := classification{Type: typeBugFix, CVEs: []cve{}}
+	for _, component := range components {
+		for _, jiraIssue := range component.Issues {
+			for _, match := range issueCVEs(jiraIssue, cveFields) {
+				entry := cve{Component: component.Name, Key: match.key}
+				if slices.Contains(c.CVEs, entry) {
+					continue
+				}
+				c.CVEs = append(c.CVEs, entry)
+				c.Reasons = append(c.Reasons, fmt.Sprintf("%s references %s in its %s, fixed in component %s",
+					jiraIssue.Key, match.key, match.source, component.Name))
+			}
+		}
+		if component.Dependencies == nil {
+			continue
+		}
+		for _, v := range component.Dependencies.Fixed {
+			for _, key := range v.CVEs {
+				entry := cve{Component: component.Name, Key: key}
+				if slices.Contains(c.CVEs, entry) {
+					continue
+				}
+				c.CVEs = append(c.CVEs, entry)
+				c.Reasons = append(c.Reasons, fmt.Sprintf("%s (%s) fixed by %s, in component %s", v.ID, key,
+					dependencyUpdate(component.Dependencies.Changes, v.Module), component.Name))
+			}
+		}
+	}
+	if len(c.CVEs) > 0 {
+		c.Type = typeSecurity
+	} else {
+		c.Reasons = []string{"no included issue references a CVE"}
+	}
+	return c
+}
+
+// withDataType returns the classification with the type of the release notes in the merged data of the Release, which
+// differs from the classified type when it is set by the release notes fragment
+func (c classification) withDataType(data []byte) (classification, error) {
+	var parsed struct {
+		ReleaseNotes struct {
+			Type string `json:"type"`
+		} `json:"releaseNotes"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return c, err
+	}
+	if parsed.ReleaseNotes.Type == "" || parsed.ReleaseNotes.Type == c.Type {
+		return c, nil
+	}
+	reason := fmt.Sprintf("set by the release notes fragment, overriding the generated type %s", c.Type)
+	c.Type = parsed.ReleaseNotes.Type
+	c.Reasons = append([]string{reason}, c.Reasons...)
+	return c, nil
+}
+
+// dependencyUpdate describes the change to the given module which fixed a vulnerability
+func dependencyUpdate(changes vuln.ModuleDiff, module string) string {
+	for _, change := range changes.Changed {
+		if change.Path == module {
+			return fmt.Sprintf("updating %s to %s", module, change.New)
+		}
+	}
+	return fmt.Sprintf("removing %s", module)
+}
+
+// notesIssue is an issue passed to the release notes template
+type notesIssue struct {
+	Key     string
+	Summary string
+}
+
+// notesData is passed to the release notes template
+type notesData struct {
+	Product string
+	Version string
+	Type    string
+	Issues  []notesIssue
+	// CVEs are the unique CVE IDs fixed
+	CVEs []string
+}
+
+// notesText holds the release notes fields rendered by the release notes template
+type notesText struct {
+	Synopsis    string `json:"synopsis"`
+	Topic       string `json:"topic"`
+	Description string `json:"description"`
+	Solution    string `json:"solution"`
+}
+
+// buildReleaseData returns the data of a Release fixing the given issues, with the product's release notes and the
+// user's fragment merged in. The result is validated before being returned.
+func buildReleaseData(jiraIssues []*jira.Issue, class classification, settings notesSettings) ([]byte, error) {
+	notes := releaseNotes{
+		Type: class.Type,
+		CVEs: class.CVEs,
+	}
+	for _, jiraIssue := range jiraIssues {
+		notes.Issues.Fixed = append(notes.Issues.Fixed, issue{
+			ID:     jiraIssue.Key,
+			Source: jira.DefaultClient.Host(),
+		})
+	}
+	var fragment map[string]interface{}
+	if len(settings.fragment) > 0 {
+		if err := yaml.Unmarshal(settings.fragment, &fragment); err != nil {
+			return nil, fmt.Errorf("error parsing release notes fragment: %w", err)
+		}
+		// The type and CVEs set by the fragment are applied before the release notes text is rendered, so that the
+		// text describes them
+		var overrides struct {
+			ReleaseNotes struct {
+				Type string `json:"type"`
+				CVEs *[]cve `json:"cves"`
+			} `json:"releaseNotes"`
+		}
+		if err := yaml.Unmarshal(settings.fragment, &overrides); err != nil {
+			return nil, fmt.Errorf("error parsing release notes fragment: %w", err)
+		}
+		if overrides.ReleaseNotes.Type != "" {
+			notes.Type = overrides.ReleaseNotes.Type
+		}
+		if overrides.ReleaseNotes.CVEs != nil {
+			notes.CVEs = *overrides.ReleaseNotes.CVEs
+		}
+	}
+	if settings.product != nil {
+		if err := addProductNotes(&notes, jiraIssues, settings.product, settings.version); err != nil {
+			return nil, err
+		}
+	}
+
+	generated, err := json.Marshal(releaseData{ReleaseNotes: notes})
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err = json.Unmarshal(generated, &data); err != nil {
+		return nil, err
+	}
+	if fragment != nil {
+		mergeData(data, fragment)
+	}
+	merged, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateReleaseData(merged); err != nil {
+		return nil, fmt.Errorf("invalid release data: %w", err)
+	}
+	return merged, nil
+}
+
+// addProductNotes renders the text of the release notes, and fills in the product's details
+func addProductNotes(notes *releaseNotes, jiraIssues []*jira.Issue, product *config.Product, version string) error {
+	data := notesData{
+		Product: product.DisplayName,
+		Version: strings.TrimPrefix(version, "v"),
+		Type:    notes.Type,
+	}
+	for _, jiraIssue := range jiraIssues {
+		data.Issues = append(data.Issues, notesIssue{Key: jiraIssue.Key, Summary: jiraIssue.Fields.Summary})
+	}
+	for _, c := range notes.CVEs {
+		if !slices.Contains(data.CVEs, c.Key) {
+			data.CVEs = append(data.CVEs, c.Key)
+		}
+	}
+	rendered, err := templates.Render(templates.ReleaseNotes, data)
+	if err != nil {
+		return err
+	}
+	var text notesText
+	if err = yaml.UnmarshalStrict([]byte(rendered), &text); err != nil {
+		return fmt.Errorf("template %s did not render valid release notes: %w", templates.ReleaseNotes, err)
+	}
+	notes.Synopsis = text.Synopsis
+	notes.Topic = text.Topic
+	notes.Description = text.Description
+	notes.Solution = text.Solution
+
+	productNotes := product.ReleaseNotes
+	notes.ProductID = productNotes.ProductID
+	notes.ProductName = productNotes.ProductName
+	if notes.ProductName == "" {
+		notes.ProductName = product.DisplayName
+	}
+	if notes.ProductVersion, err = config.Render(productNotes.ProductVersion, version); err != nil {
+		return err
+	}
+	if notes.ProductStream, err = config.Render(productNotes.ProductStream, version); err != nil {
+		return err
+	}
+	notes.CPE = productNotes.CPE
+	notes.References = slices.Clone(productNotes.References)
+	for _, c := range data.CVEs {
+		notes.References = append(notes.References, cveReferenceURL+c)
+	}
+	return nil
+}
+
+// mergeData merges overlay into base. Maps are merged recursively, any other value in overlay replaces that in base.
+func mergeData(base, overlay map[string]interface{}) {
+	for key, value := range overlay {
+		overlayMap, isMap := value.(map[string]interface{})
+		baseMap, baseIsMap := base[key].(map[string]interface{})
+		if isMap && baseIsMap {
+			mergeData(baseMap, overlayMap)
+			continue
+		}
+		base[key] = value
+	}
+}
+
+// validateReleaseData checks the release notes in the data of a Release against the schema accepted by the release
+// pipelines
+func validateReleaseData(data []byte) error {
+	var raw struct {
+		ReleaseNotes json.RawMessage `json:"releaseNotes"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw.ReleaseNotes))
+	decoder.DisallowUnknownFields()
+	var notes releaseNotes
+	if err := decoder.Decode(&notes); err != nil {
+		return fmt.Errorf("releaseNotes: %w", err)
+	}
+
+	var errs []error
+	switch notes.Type {
+	case typeSecurity:
+		if len(notes.CVEs) == 0 {
+			errs = append(errs, fmt.Errorf("releaseNotes.type %s requires at least one CVE", typeSecurity))
+		}
+	case typeBugFix, typeEnhancement:
+		if len(notes.CVEs) > 0 {
+			errs = append(errs, fmt.Errorf("releaseNotes.type %s cannot list CVEs, releases fixing CVEs are %s",
+				notes.Type, typeSecurity))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("releaseNotes.type must be one of %s, %s or %s, got %q", typeSecurity,
+			typeBugFix, typeEnhancement, notes.Type))
+	}
+	for i, c := range notes.CVEs {
+		if !cveIDRegex.MatchString(c.Key) {
+			errs = append(errs, fmt.Errorf("releaseNotes.cves[%d].key %q is not a CVE ID", i, c.Key))
+		}
+		if c.Component == "" {
+			errs = append(errs, fmt.Errorf("releaseNotes.cves[%d].component must be set", i))
+		}
+	}
+	for i, fixed := range notes.Issues.Fixed {
+		if fixed.ID == "" || fixed.Source == "" {
+			errs = append(errs, fmt.Errorf("releaseNotes.issues.fixed[%d] must have an id and source", i))
+		}
+	}
+	for i, reference := range notes.References {
+		if u, err := url.Parse(reference); err != nil || !u.IsAbs() {
+			errs = append(errs, fmt.Errorf("releaseNotes.references[%d] %q is not an absolute URL", i, reference))
+		}
+	}
+	for i, id := range notes.ProductID {
+		if id <= 0 {
+			errs = append(errs, fmt.Errorf("releaseNotes.product_id[%d] must be positive", i))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,224 @@
+package konflux
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/pkg/jira"
+	"github.com/sebsoto/gojira/pkg/vuln"
+)
+
+func TestIssueCVEs(t *testing.T) {
+	issue := &jira.Issue{Key: "OCPBUGS-1"}
+	issue.Fields.Summary = "[CVE-2025-1111] golang: net/http: also fixes CVE-2025-2222"
+	issue.Fields.Labels = []string{"SecurityTracking", "CVE-2025-3333", "CVE-2025-1111"}
+	issue.Fields.Custom = map[string]json.RawMessage{
+		"customfield_1": json.RawMessage(`["CVE-2025-44444"]`),
+		"customfield_2": json.RawMessage(`"CVE-2025-55555"`),
+	}
+
+	var found []string
+	for _, match := range issueCVEs(issue, []string{"customfield_1"}) {
+		found = append(found, match.key+" "+match.source)
+	}
+	expected := []string{
+		"CVE-2025-3333 label",
+		"CVE-2025-1111 label",
+		"CVE-2025-2222 summary",
+		"CVE-2025-44444 customfield_1",
+	}
+	if !slices.Equal(found, expected) {
+		t.Errorf("expected %v, got %v", expected, found)
+	}
+}
+
+func TestClassifyRelease(t *testing.T) {
+	bug := &jira.Issue{Key: "WINC-1"}
+	bug.Fields.Summary = "Fix node upgrades"
+	class := classifyRelease([]*ComponentChanges{{Name: "operator", Issues: []*jira.Issue{bug}}}, nil)
+	if class.Type != typeBugFix || len(class.CVEs) != 0 || len(class.Reasons) != 1 {
+		t.Errorf("unexpected classification %+v", class)
+	}
+
+	vulnerability := &jira.Issue{Key: "OCPBUGS-2"}
+	vulnerability.Fields.Summary = "windows-machine-config-operator: [CVE-2025-1111] golang: crash"
+	class = classifyRelease([]*ComponentChanges{
+		{Name: "operator", Issues: []*jira.Issue{bug, vulnerability}},
+		{Name: "bundle", Issues: []*jira.Issue{bug}},
+	}, nil)
+	if class.Type != typeSecurity {
+		t.Errorf("expected %s, got %s", typeSecurity, class.Type)
+	}
+	if len(class.CVEs) != 1 || class.CVEs[0] != (cve{Component: "operator", Key: "CVE-2025-1111"}) {
+		t.Errorf("unexpected CVEs %v", class.CVEs)
+	}
+	if len(class.Reasons) != 1 || !strings.Contains(class.Reasons[0], "OCPBUGS-2 references CVE-2025-1111 in its summary") {
+		t.Errorf("unexpected reasons %v", class.Reasons)
+	}
+
+	dependencies := &vuln.Report{
+		Changes: vuln.ModuleDiff{Changed: []vuln.ModuleChange{{Path: "golang.org/x/net", Old: "v0.22.0", New: "v0.23.0"}}},
+		Fixed:   []vuln.Vulnerability{{ID: "GO-2024-0001", CVEs: []string{"CVE-2024-0001"}, Module: "golang.org/x/net"}},
+	}
+	class = classifyRelease([]*ComponentChanges{{Name: "operator", Issues: []*jira.Issue{bug}, Dependencies: dependencies}}, nil)
+	if class.Type != typeSecurity || len(class.CVEs) != 1 || class.CVEs[0].Key != "CVE-2024-0001" {
+		t.Errorf("unexpected classification %+v", class)
+	}
+	if len(class.Reasons) != 1 || !strings.Contains(class.Reasons[0], "updating golang.org/x/net to v0.23.0") {
+		t.Errorf("unexpected reasons %v", class.Reasons)
+	}
+}
+
+func TestBuildReleaseData(t *testing.T) {
+	vulnerability := &jira.Issue{Key: "OCPBUGS-2"}
+	vulnerability.Fields.Summary = "[CVE-2025-1111] golang: crash"
+	class := classifyRelease([]*ComponentChanges{{Name: "operator", Issues: []*jira.Issue{vulnerability}}}, nil)
+	product := config.DefaultProduct()
+	product.ReleaseNotes = config.ReleaseNotes{
+		ProductID:      []int{123},
+		ProductVersion: "{{ .Major }}.{{ .Minor }}",
+		References:     []string{"https://docs.example.com/wmco"},
+	}
+	fragment := []byte(`
+releaseNotes:
+  solution: See the docs
+  issues:
+    fixed:
+    - id: WINC-9
+      source: issues.redhat.com
+mapping:
+  defaults:
+    tags: [latest]
+`)
+	raw, err := buildReleaseData([]*jira.Issue{vulnerability}, class, notesSettings{
+		version:  "v10.19.1",
+		product:  product,
+		fragment: fragment,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data struct {
+		ReleaseNotes releaseNotes           `json:"releaseNotes"`
+		Mapping      map[string]interface{} `json:"mapping"`
+	}
+	if err = json.Unmarshal(raw, &data); err != nil {
+		t.Fatal(err)
+	}
+	notes := data.ReleaseNotes
+	if notes.Synopsis != "Windows Machine Config Operator 10.19.1 security and bug fix update" {
+		t.Errorf("unexpected synopsis %q", notes.Synopsis)
+	}
+	if !strings.Contains(notes.Description, "* OCPBUGS-2: [CVE-2025-1111] golang: crash") {
+		t.Errorf("issue missing from description %q", notes.Description)
+	}
+	if notes.Solution != "See the docs" {
+		t.Errorf("expected fragment to override the solution, got %q", notes.Solution)
+	}
+	if len(notes.Issues.Fixed) != 1 || notes.Issues.Fixed[0].ID != "WINC-9" {
+		t.Errorf("expected fragment to replace the fixed issues, got %v", notes.Issues.Fixed)
+	}
+	if len(notes.CVEs) != 1 || notes.Type != typeSecurity {
+		t.Errorf("expected generated fields to be kept, got %+v", notes)
+	}
+	if notes.ProductVersion != "10.19" || notes.ProductName != product.DisplayName || notes.ProductID[0] != 123 {
+		t.Errorf("unexpected product details %+v", notes)
+	}
+	expectedReferences := []string{"https://docs.example.com/wmco", cveReferenceURL + "CVE-2025-1111"}
+	if !slices.Equal(notes.References, expectedReferences) {
+		t.Errorf("expected references %v, got %v", expectedReferences, notes.References)
+	}
+	if data.Mapping == nil {
+		t.Error("expected keys outside the release notes to be kept")
+	}
+
+	if kept, err := class.withDataType(raw); err != nil || kept.Type != typeSecurity || len(kept.Reasons) != 1 {
+		t.Errorf("expected the generated type to be kept, got %+v, %v", kept, err)
+	}
+	if _, err = buildReleaseData([]*jira.Issue{vulnerability}, class, notesSettings{
+		version:  "v10.19.1",
+		fragment: []byte("releaseNotes:\n  type: RHBA\n"),
+	}); err == nil {
+		t.Error("expected a bug fix type conflicting with the fixed CVEs to be rejected")
+	}
+	raw, err = buildReleaseData([]*jira.Issue{vulnerability}, class, notesSettings{
+		version:  "v10.19.1",
+		product:  product,
+		fragment: []byte("releaseNotes:\n  type: RHBA\n  cves: []\n"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(raw, &data); err != nil {
+		t.Fatal(err)
+	}
+	if synopsis := data.ReleaseNotes.Synopsis; synopsis != "Windows Machine Config Operator 10.19.1 bug fix update" {
+		t.Errorf("expected the text to be rendered with the fragment's type, got %q", synopsis)
+	}
+	if strings.Contains(data.ReleaseNotes.Description, "Security fixes") {
+		t.Errorf("expected no security fixes in the description, got %q", data.ReleaseNotes.Description)
+	}
+	overridden, err := class.withDataType(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overridden.Type != typeBugFix || len(overridden.Reasons) != 2 ||
+		!strings.Contains(overridden.Reasons[0], "release notes fragment") {
+		t.Errorf("expected the fragment's type, got %+v", overridden)
+	}
+}
+
+func TestValidateReleaseData(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name: "valid",
+			data: `{"releaseNotes":{"type":"RHBA","cves":[],"issues":{"fixed":[{"id":"WINC-1","source":"issues.redhat.com"}]}}}`,
+		},
+		{
+			name:     "unknown field",
+			data:     `{"releaseNotes":{"type":"RHBA","synopis":"typo"}}`,
+			expected: "unknown field",
+		},
+		{
+			name:     "unknown type",
+			data:     `{"releaseNotes":{"type":"RHXA"}}`,
+			expected: "releaseNotes.type must be one of",
+		},
+		{
+			name:     "security advisory without CVEs",
+			data:     `{"releaseNotes":{"type":"RHSA","cves":[]}}`,
+			expected: "requires at least one CVE",
+		},
+		{
+			name:     "invalid CVE",
+			data:     `{"releaseNotes":{"type":"RHSA","cves":[{"key":"CVE-1","component":"operator"}]}}`,
+			expected: "is not a CVE ID",
+		},
+		{
+			name:     "relative reference",
+			data:     `{"releaseNotes":{"type":"RHBA","references":["docs/wmco"]}}`,
+			expected: "is not an absolute URL",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateReleaseData([]byte(tc.data))
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
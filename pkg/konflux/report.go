@@ -0,0 +1,153 @@
+package konflux
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sebsoto/gojira/pkg/git"
+	"github.com/sebsoto/gojira/pkg/vuln"
+)
+
+// Report is a stable, serializable description of a Release, for consumption by automation
+type Report struct {
+	Snapshot      SnapshotReport    `json:"snapshot"`
+	Tests         TestResultsReport `json:"tests"`
+	Components    []ComponentReport `json:"components"`
+	MissingMerges []CommitReport    `json:"missingMerges"`
+	Merges        []CommitReport    `json:"merges"`
+	Issues        []IssueReport     `json:"issues"`
+	// Type is the advisory type of the release, and TypeReasons explain why it was chosen
+	Type        string      `json:"type"`
+	TypeReasons []string    `json:"typeReasons"`
+	CVEs        []CVEReport `json:"cves"`
+	// Release is the manifest of the Konflux Release
+	Release map[string]interface{} `json:"release"`
+}
+
+type SnapshotReport struct {
+	Name string `json:"name"`
+	// Provenance describes how the snapshot was selected
+	Provenance string    `json:"provenance"`
+	Timestamp  time.Time `json:"timestamp"`
+	Commit     string    `json:"commit"`
+}
+
+type TestResultsReport struct {
+	Passed    bool             `json:"passed"`
+	Summary   string           `json:"summary"`
+	Scenarios []ScenarioStatus `json:"scenarios"`
+}
+
+type ComponentReport struct {
+	Name          string   `json:"name"`
+	GitURL        string   `json:"gitURL"`
+	Commit        string   `json:"commit"`
+	MissingMerges []string `json:"missingMerges"`
+	Merges        []string `json:"merges"`
+	Issues        []string `json:"issues"`
+	// Dependencies is set when the component's Go dependencies were checked for vulnerabilities
+	Dependencies *vuln.Report `json:"dependencies,omitempty"`
+}
+
+type CommitReport struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+}
+
+type IssueReport struct {
+	Key         string   `json:"key"`
+	Summary     string   `json:"summary"`
+	Status      string   `json:"status"`
+	FixVersions []string `json:"fixVersions"`
+}
+
+type CVEReport struct {
+	Component string `json:"component"`
+	Key       string `json:"key"`
+}
+
+// Report returns the description of the release. Lists are never nil, so that they are serialized as empty lists.
+func (r *Release) Report() (*Report, error) {
+	report := &Report{
+		Snapshot: SnapshotReport{
+			Name:       r.Snapshot.GetName(),
+			Provenance: r.Provenance,
+			Timestamp:  r.Snapshot.GetCreationTimestamp().Time,
+			Commit:     r.Sha,
+		},
+		Tests: TestResultsReport{
+			Passed:    r.TestStatus.Passed(),
+			Summary:   r.TestStatus.Summary(),
+			Scenarios: append([]ScenarioStatus{}, r.TestStatus.Scenarios...),
+		},
+		Components:    []ComponentReport{},
+		MissingMerges: commitReports(r.MissingMerges),
+		Merges:        commitReports(r.Merges),
+		Issues:        []IssueReport{},
+		Type:          r.Type,
+		TypeReasons:   append([]string{}, r.TypeReasons...),
+		CVEs:          []CVEReport{},
+	}
+	for _, component := range r.Components {
+		componentReport := ComponentReport{
+			Name:          component.Name,
+			GitURL:        component.GitURL,
+			Commit:        component.Sha,
+			MissingMerges: []string{},
+			Merges:        []string{},
+			Issues:        []string{},
+			Dependencies:  component.Dependencies,
+		}
+		for _, commit := range component.MissingMerges {
+			componentReport.MissingMerges = append(componentReport.MissingMerges, commit.SHA)
+		}
+		for _, commit := range component.Merges {
+			componentReport.Merges = append(componentReport.Merges, commit.SHA)
+		}
+		for _, issue := range component.Issues {
+			componentReport.Issues = append(componentReport.Issues, issue.Key)
+		}
+		report.Components = append(report.Components, componentReport)
+	}
+	for _, issue := range r.Issues {
+		issueReport := IssueReport{
+			Key:         issue.Key,
+			Summary:     issue.Fields.Summary,
+			FixVersions: []string{},
+		}
+		if issue.Fields.Status != nil {
+			issueReport.Status = issue.Fields.Status.Name
+		}
+		for _, fixVersion := range issue.Fields.FixVersions {
+			issueReport.FixVersions = append(issueReport.FixVersions, fixVersion.Name)
+		}
+		report.Issues = append(report.Issues, issueReport)
+	}
+
+	var data releaseData
+	if err := json.Unmarshal(r.Release.Spec.Data.Raw, &data); err != nil {
+		return nil, err
+	}
+	for _, c := range data.ReleaseNotes.CVEs {
+		report.CVEs = append(report.CVEs, CVEReport{Component: c.Component, Key: c.Key})
+	}
+
+	manifest, err := json.Marshal(r.Release)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(manifest, &report.Release); err != nil {
+		return nil, err
+	}
+	// The Release has not been created, so its status is always empty
+	delete(report.Release, "status")
+	return report, nil
+}
+
+func commitReports(commits []git.Commit) []CommitReport {
+	reports := []CommitReport{}
+	for _, commit := range commits {
+		reports = append(reports, CommitReport{SHA: commit.SHA, Message: commit.Message})
+	}
+	return reports
+}
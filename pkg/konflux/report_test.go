@@ -0,0 +1,71 @@
+package konflux
+
+import (
+	"encoding/json"
+	"testing"
+
+	applicationv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sebsoto/gojira/pkg/git"
+	"github.com/sebsoto/gojira/pkg/jira"
+)
+
+func TestReport(t *testing.T) {
+	issue := &jira.Issue{Key: "OCPBUGS-1"}
+	issue.Fields.Summary = "CVE-2024-1234 golang: net/http: memory exhaustion"
+	issue.Fields.Status = &jira.Status{Name: "Verified"}
+	issue.Fields.FixVersions = []jira.FixVersion{{Name: "WMCO 10.19.0"}}
+	component := &ComponentChanges{
+		Name:   "operator",
+		GitURL: "https://github.com/openshift/windows-machine-config-operator",
+		Sha:    "aaa",
+		Merges: []git.Commit{{SHA: "aaa", Message: "Merge OCPBUGS-1"}},
+		Issues: []*jira.Issue{issue},
+	}
+	class := classifyRelease([]*ComponentChanges{component}, nil)
+	data, err := buildReleaseData(component.Issues, class, notesSettings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &Release{
+		Release:     newRelease("plan", "snap", data),
+		Type:        class.Type,
+		TypeReasons: class.Reasons,
+		Components:  []*ComponentChanges{component},
+		Merges:      component.Merges,
+		Issues:      component.Issues,
+		Snapshot:    &applicationv1alpha1.Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "snap"}},
+		Sha:         "aaa",
+		TestStatus:  &TestStatus{},
+	}
+	report, err := r.Report()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err = json.Unmarshal(out, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"snapshot", "tests", "type", "typeReasons", "components", "missingMerges", "merges", "issues", "cves", "release"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("report is missing %s: %s", key, out)
+		}
+	}
+	if missing, ok := decoded["missingMerges"].([]interface{}); !ok || len(missing) != 0 {
+		t.Errorf("expected missing merges to be an empty list, got %v", decoded["missingMerges"])
+	}
+	if _, ok := report.Release["status"]; ok {
+		t.Error("expected release status to be omitted")
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Status != "Verified" || report.Issues[0].FixVersions[0] != "WMCO 10.19.0" {
+		t.Errorf("unexpected issues %v", report.Issues)
+	}
+	if len(report.CVEs) != 1 || report.CVEs[0].Key != "CVE-2024-1234" || report.CVEs[0].Component != "operator" {
+		t.Errorf("unexpected CVEs %v", report.CVEs)
+	}
+}
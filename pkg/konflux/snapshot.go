@@ -0,0 +1,125 @@
+package konflux
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	applicationv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	releasev1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SnapshotLatestRelease selects the snapshot of the latest successful Release of the application
+	SnapshotLatestRelease = "latest-release"
+	// SnapshotName selects a snapshot by name
+	SnapshotName = "name"
+	// SnapshotCommit selects the newest snapshot built from a commit
+	SnapshotCommit = "commit"
+	// SnapshotPassing selects the newest snapshot whose integration tests all passed
+	SnapshotPassing = "passing"
+)
+
+// testSucceededCondition is set on a snapshot once all of its integration tests have finished
+const testSucceededCondition = "AppStudioTestSucceeded"
+
+// SnapshotSelector describes how the snapshot of a release is chosen
+type SnapshotSelector struct {
+	// Kind is one of SnapshotLatestRelease, SnapshotName, SnapshotCommit or SnapshotPassing
+	Kind string
+	// Value is the snapshot name or commit SHA, depending on Kind
+	Value string
+}
+
+// ParseSnapshotSelector parses a selector of the form "latest-release", "passing", "name:<snapshot>" or
+// "commit:<sha>". Any other value is treated as a snapshot name. An empty selector selects the latest release.
+func ParseSnapshotSelector(selector string) (SnapshotSelector, error) {
+	kind, value, found := strings.Cut(selector, ":")
+	switch {
+	case selector == "" || selector == SnapshotLatestRelease:
+		return SnapshotSelector{Kind: SnapshotLatestRelease}, nil
+	case selector == SnapshotPassing:
+		return SnapshotSelector{Kind: SnapshotPassing}, nil
+	case !found:
+		return SnapshotSelector{Kind: SnapshotName, Value: selector}, nil
+	case kind == SnapshotName || kind == SnapshotCommit:
+		if value == "" {
+			return SnapshotSelector{}, fmt.Errorf("snapshot selector %s has no value", selector)
+		}
+		return SnapshotSelector{Kind: kind, Value: value}, nil
+	}
+	return SnapshotSelector{}, fmt.Errorf("unknown snapshot selector: %s", selector)
+}
+
+// selectSnapshot returns the snapshot of the application chosen by the selector, along with a description of how it
+// was chosen
+func selectSnapshot(ctx context.Context, c client.Client, namespace, application string,
+	selector SnapshotSelector) (*applicationv1alpha1.Snapshot, string, error) {
+	var snapshotName, provenance string
+	switch selector.Kind {
+	case SnapshotLatestRelease, "":
+		var relList releasev1alpha1.ReleaseList
+		err := c.List(ctx, &relList, client.MatchingLabels{"appstudio.openshift.io/application": application},
+			client.InNamespace(namespace))
+		if err != nil {
+			return nil, "", err
+		}
+		lastRelease, err := latestRelease(relList.Items)
+		if err != nil {
+			return nil, "", err
+		}
+		snapshotName = lastRelease.Spec.Snapshot
+		provenance = fmt.Sprintf("used by latest successful release %s", lastRelease.GetName())
+	case SnapshotName:
+		snapshotName = selector.Value
+		provenance = "selected by name"
+	case SnapshotCommit, SnapshotPassing:
+		var snapList applicationv1alpha1.SnapshotList
+		err := c.List(ctx, &snapList, client.MatchingLabels{"appstudio.openshift.io/application": application},
+			client.InNamespace(namespace))
+		if err != nil {
+			return nil, "", err
+		}
+		matches := slices.DeleteFunc(snapList.Items, func(snap applicationv1alpha1.Snapshot) bool {
+			if selector.Kind == SnapshotCommit {
+				return !builtFromCommit(&snap, selector.Value)
+			}
+			return !meta.IsStatusConditionTrue(snap.Status.Conditions, testSucceededCondition)
+		})
+		if len(matches) == 0 {
+			return nil, "", fmt.Errorf("no snapshot of %s found matching %s %s", application, selector.Kind,
+				selector.Value)
+		}
+		newest := slices.MaxFunc(matches, func(a, b applicationv1alpha1.Snapshot) int {
+			return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+		})
+		if selector.Kind == SnapshotCommit {
+			provenance = fmt.Sprintf("newest snapshot built from commit %s", selector.Value)
+		} else {
+			provenance = "newest snapshot with passing integration tests"
+		}
+		return &newest, provenance, nil
+	default:
+		return nil, "", fmt.Errorf("unknown snapshot selector: %s", selector.Kind)
+	}
+	var snap applicationv1alpha1.Snapshot
+	if err := c.Get(ctx, types.NamespacedName{Name: snapshotName, Namespace: namespace}, &snap); err != nil {
+		return nil, "", err
+	}
+	return &snap, provenance, nil
+}
+
+// builtFromCommit returns true if any component of the snapshot was built from the commit with the given SHA, which
+// may be abbreviated
+func builtFromCommit(snap *applicationv1alpha1.Snapshot, sha string) bool {
+	for _, component := range snap.Spec.Components {
+		if component.Source.GitSource != nil && strings.HasPrefix(component.Source.GitSource.Revision, sha) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package konflux
+
+import (
+	"testing"
+)
+
+func TestParseSnapshotSelector(t *testing.T) {
+	testCases := []struct {
+		selector    string
+		expected    SnapshotSelector
+		expectedErr bool
+	}{
+		{selector: "", expected: SnapshotSelector{Kind: SnapshotLatestRelease}},
+		{selector: "latest-release", expected: SnapshotSelector{Kind: SnapshotLatestRelease}},
+		{selector: "passing", expected: SnapshotSelector{Kind: SnapshotPassing}},
+		{selector: "wmco-10-19-abcde", expected: SnapshotSelector{Kind: SnapshotName, Value: "wmco-10-19-abcde"}},
+		{selector: "name:passing", expected: SnapshotSelector{Kind: SnapshotName, Value: "passing"}},
+		{selector: "commit:1a2b3c", expected: SnapshotSelector{Kind: SnapshotCommit, Value: "1a2b3c"}},
+		{selector: "commit:", expectedErr: true},
+		{selector: "tag:v10.19.0", expectedErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.selector, func(t *testing.T) {
+			actual, err := ParseSnapshotSelector(tc.selector)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package konflux
+
+import (
+	"encoding/json"
+	"fmt"
+
+	applicationv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sebsoto/gojira/pkg/config"
+)
+
+// testStatusAnnotation holds the status of each integration test scenario run against a snapshot
+const testStatusAnnotation = "test.appstudio.openshift.io/status"
+
+// scenarioPassed is the status of a scenario whose tests passed
+const scenarioPassed = "TestPassed"
+
+// ScenarioStatus is the result of an integration test scenario run against a snapshot
+type ScenarioStatus struct {
+	Scenario            string `json:"scenario"`
+	Status              string `json:"status"`
+	Details             string `json:"details,omitempty"`
+	TestPipelineRunName string `json:"testPipelineRunName,omitempty"`
+}
+
+// Passed returns true if the scenario's tests passed
+func (s ScenarioStatus) Passed() bool {
+	return s.Status == scenarioPassed
+}
+
+// TestStatus is the integration test status of a snapshot
+type TestStatus struct {
+	// Scenarios holds the status of each test scenario
+	Scenarios []ScenarioStatus
+	// Condition is the snapshot's AppStudioTestSucceeded condition, which is nil until all tests have finished
+	Condition *metav1.Condition
+}
+
+// snapshotTestStatus reads the integration test status annotation and condition of the snapshot
+func snapshotTestStatus(snap *applicationv1alpha1.Snapshot) (*TestStatus, error) {
+	status := &TestStatus{
+		Condition: meta.FindStatusCondition(snap.Status.Conditions, testSucceededCondition),
+	}
+	if annotation, ok := snap.Annotations[testStatusAnnotation]; ok {
+		if err := json.Unmarshal([]byte(annotation), &status.Scenarios); err != nil {
+			return nil, fmt.Errorf("unable to parse %s annotation of snapshot %s: %w", testStatusAnnotation,
+				snap.GetName(), err)
+		}
+	}
+	return status, nil
+}
+
+// Passed returns true if the snapshot's tests have finished and every scenario passed
+func (t *TestStatus) Passed() bool {
+	if t.Condition != nil && t.Condition.Status != metav1.ConditionTrue {
+		return false
+	}
+	if t.Condition == nil && len(t.Scenarios) == 0 {
+		return false
+	}
+	for _, scenario := range t.Scenarios {
+		if !scenario.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Summary describes the overall test result
+func (t *TestStatus) Summary() string {
+	if t.Passed() {
+		return "passed"
+	}
+	if t.Condition == nil {
+		if len(t.Scenarios) == 0 {
+			return "no integration test results found"
+		}
+		return "pending"
+	}
+	return fmt.Sprintf("failed: %s", t.Condition.Message)
+}
+
+// TestsNotPassedError is returned when a release is generated for a snapshot whose integration tests have not passed
+type TestsNotPassedError struct {
+	Snapshot string
+	Summary  string
+}
+
+func (e *TestsNotPassedError) Error() string {
+	return fmt.Sprintf("integration tests of snapshot %s have not passed (%s), use --force to release it anyway",
+		e.Snapshot, e.Summary)
+}
+
+// planEnvironment returns whether the named ReleasePlan is the product's production or stage plan, and empty if it is
+// neither or no product is given
+func planEnvironment(product *config.Product, name string) string {
+	if product == nil {
+		return ""
+	}
+	return product.ReleasePlanEnvironment(name)
+}
+
+// CheckTests returns a TestsNotPassedError if the integration tests of the release's snapshot have not all passed,
+// unless force is set. Releases for the product's stage ReleasePlan are where snapshots get tested, and are not
+// checked. Any other ReleasePlan, including one which cannot be matched against the product's ReleasePlans, is treated
+// as production.
+func (r *Release) CheckTests(force bool) error {
+	if force || r.Environment == config.EnvironmentStage || r.TestStatus.Passed() {
+		return nil
+	}
+	return &TestsNotPassedError{Snapshot: r.Snapshot.GetName(), Summary: r.TestStatus.Summary()}
+}
@@ -0,0 +1,79 @@
+package konflux
+
+import (
+	"testing"
+
+	applicationv1alpha1 "github.com/konflux-ci/application-api/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sebsoto/gojira/pkg/config"
+)
+
+func TestSnapshotTestStatus(t *testing.T) {
+	succeeded := metav1.Condition{Type: testSucceededCondition, Status: metav1.ConditionTrue, Reason: "Passed"}
+	failed := metav1.Condition{Type: testSucceededCondition, Status: metav1.ConditionFalse, Reason: "Failed",
+		Message: "Some Integration pipeline tests failed"}
+	testCases := []struct {
+		name       string
+		annotation string
+		conditions []metav1.Condition
+		passed     bool
+	}{
+		{
+			name:       "all passed",
+			annotation: `[{"scenario":"e2e","status":"TestPassed"},{"scenario":"ec","status":"TestPassed"}]`,
+			conditions: []metav1.Condition{succeeded},
+			passed:     true,
+		},
+		{
+			name:       "scenario failed",
+			annotation: `[{"scenario":"e2e","status":"TestFail","details":"failed"},{"scenario":"ec","status":"TestPassed"}]`,
+			conditions: []metav1.Condition{failed},
+		},
+		{
+			name:       "scenario pending",
+			annotation: `[{"scenario":"e2e","status":"InProgress"}]`,
+		},
+		{
+			name: "no results",
+		},
+		{
+			name:       "condition only",
+			conditions: []metav1.Condition{succeeded},
+			passed:     true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			snap := &applicationv1alpha1.Snapshot{}
+			if tc.annotation != "" {
+				snap.Annotations = map[string]string{testStatusAnnotation: tc.annotation}
+			}
+			snap.Status.Conditions = tc.conditions
+			status, err := snapshotTestStatus(snap)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Passed() != tc.passed {
+				t.Errorf("expected passed to be %t, summary: %s", tc.passed, status.Summary())
+			}
+			r := &Release{Snapshot: snap, TestStatus: status, Environment: config.EnvironmentProd}
+			if err = r.CheckTests(false); (err == nil) != tc.passed {
+				t.Errorf("unexpected CheckTests result %v", err)
+			}
+			if err = r.CheckTests(true); err != nil {
+				t.Errorf("expected force to skip the check, got %v", err)
+			}
+			r.Environment = config.EnvironmentStage
+			if err = r.CheckTests(false); err != nil {
+				t.Errorf("expected stage releases not to be checked, got %v", err)
+			}
+			for _, product := range []*config.Product{nil, config.DefaultProduct()} {
+				r.Environment = planEnvironment(product, "windows-machine-config-operator-10-19-prod")
+				if err = r.CheckTests(false); (err == nil) != tc.passed {
+					t.Errorf("expected unclassified releases to be checked, got %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package konflux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	releasev1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ReleaseFailedError is returned by WatchRelease when the Release finishes without being released
+type ReleaseFailedError struct {
+	Name    string
+	Reason  string
+	Message string
+}
+
+func (e *ReleaseFailedError) Error() string {
+	return fmt.Sprintf("release %s failed: %s: %s", e.Name, e.Reason, e.Message)
+}
+
+// WatchRelease polls the named Release every interval, writing changes to its conditions and the PipelineRuns
+// processing it to out, until the Release finishes or ctx is done. A ReleaseFailedError is returned if the Release
+// finishes without being released. Errors getting the Release are retried on the next poll, unless they are not
+// transient.
+func WatchRelease(ctx context.Context, namespace, name string, interval time.Duration, out io.Writer) (*releasev1alpha1.Release, error) {
+	c, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	var previous releasev1alpha1.Release
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var current releasev1alpha1.Release
+		if err = c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &current); err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("stopped watching release %s: %w", name, ctx.Err())
+			}
+			if !isTransient(err) {
+				return nil, err
+			}
+			fmt.Fprintf(os.Stderr, "WARNING: error getting release %s, retrying: %s\n", name, err)
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("stopped watching release %s: %w", name, ctx.Err())
+			case <-ticker.C:
+			}
+			continue
+		}
+		for _, condition := range conditionChanges(previous.Status.Conditions, current.Status.Conditions) {
+			fmt.Fprintf(out, "%s %s=%s (%s)", condition.LastTransitionTime.Format(time.DateTime), condition.Type,
+				condition.Status, condition.Reason)
+			if condition.Message != "" {
+				fmt.Fprintf(out, ": %s", condition.Message)
+			}
+			fmt.Fprintln(out)
+		}
+		for _, pipeline := range []struct {
+			name              string
+			previous, current string
+		}{
+			{"Tenant", previous.Status.TenantProcessing.PipelineRun, current.Status.TenantProcessing.PipelineRun},
+			{"Managed", previous.Status.ManagedProcessing.PipelineRun, current.Status.ManagedProcessing.PipelineRun},
+			{"Final", previous.Status.FinalProcessing.PipelineRun, current.Status.FinalProcessing.PipelineRun},
+		} {
+			if pipeline.current != "" && pipeline.current != pipeline.previous {
+				fmt.Fprintf(out, "%s PipelineRun: %s\n", pipeline.name, pipeline.current)
+			}
+		}
+		if current.HasReleaseFinished() {
+			if current.IsReleased() {
+				return &current, nil
+			}
+			failure := &ReleaseFailedError{Name: name}
+			for _, condition := range current.Status.Conditions {
+				if condition.Type == "Released" {
+					failure.Reason = condition.Reason
+					failure.Message = condition.Message
+				}
+			}
+			return &current, failure
+		}
+		previous = current
+		select {
+		case <-ctx.Done():
+			return &current, fmt.Errorf("stopped watching release %s: %w", name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTransient reports whether an error getting a Release may go away on retry. Errors caused by the request itself,
+// such as a missing Release or denied access, are not transient.
+func isTransient(err error) bool {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	case apierrors.IsNotFound(err), apierrors.IsForbidden(err), apierrors.IsUnauthorized(err),
+		apierrors.IsBadRequest(err), apierrors.IsInvalid(err), apierrors.IsMethodNotSupported(err):
+		return false
+	}
+	return true
+}
+
+// conditionChanges returns the conditions in current which are new or differ from those in previous
+func conditionChanges(previous, current []metav1.Condition) []metav1.Condition {
+	var changes []metav1.Condition
+	for _, condition := range current {
+		changed := true
+		for _, prev := range previous {
+			if prev.Type == condition.Type && prev.Status == condition.Status && prev.Reason == condition.Reason &&
+				prev.Message == condition.Message {
+				changed = false
+				break
+			}
+		}
+		if changed {
+			changes = append(changes, condition)
+		}
+	}
+	return changes
+}
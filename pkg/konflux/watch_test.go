@@ -0,0 +1,53 @@
+package konflux
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestConditionChanges(t *testing.T) {
+	previous := []metav1.Condition{
+		{Type: "Validated", Status: metav1.ConditionTrue, Reason: "Succeeded"},
+		{Type: "ManagedPipelineProcessed", Status: metav1.ConditionFalse, Reason: "Progressing"},
+	}
+	current := []metav1.Condition{
+		{Type: "Validated", Status: metav1.ConditionTrue, Reason: "Succeeded"},
+		{Type: "ManagedPipelineProcessed", Status: metav1.ConditionFalse, Reason: "Failed", Message: "task failed"},
+		{Type: "Released", Status: metav1.ConditionFalse, Reason: "Failed"},
+	}
+	changes := conditionChanges(previous, current)
+	if len(changes) != 2 || changes[0].Type != "ManagedPipelineProcessed" || changes[1].Type != "Released" {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+	if changes := conditionChanges(current, current); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	resource := schema.GroupResource{Group: "appstudio.redhat.com", Resource: "releases"}
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{"timeout", apierrors.NewTimeoutError("timed out", 1), true},
+		{"unavailable", apierrors.NewServiceUnavailable("unavailable"), true},
+		{"connection", errors.New("connection refused"), true},
+		{"not found", apierrors.NewNotFound(resource, "release"), false},
+		{"forbidden", apierrors.NewForbidden(resource, "release", errors.New("denied")), false},
+		{"canceled", context.Canceled, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if transient := isTransient(test.err); transient != test.transient {
+				t.Errorf("expected transient %t, got %t", test.transient, transient)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sebsoto/gojira/pkg/jira"
+	"github.com/sebsoto/gojira/pkg/konflux"
+	"github.com/sebsoto/gojira/templates"
+)
+
+// announcementMarker is added to announcement comments, followed by the namespace and name of the Konflux Release, so
+// that the comment can be found again
+const announcementMarker = "gojira-announcement:"
+
+// Announcement holds the values the announcement template is rendered with
+type Announcement struct {
+	Product  string
+	Version  string
+	Release  string
+	Snapshot string
+	// Link is the URL of the Konflux Release
+	Link string
+	// Advisory is the URL of the advisory published by the Release, if known
+	Advisory string
+	Images   []konflux.ComponentImage
+	// namespace is the namespace of the Konflux Release, used to identify its announcement comments
+	namespace string
+}
+
+// NewAnnouncement returns the announcement of the given shipped Konflux Release
+func NewAnnouncement(product, version, namespace, link string, rel *konflux.HistoryEntry) *Announcement {
+	return &Announcement{
+		Product:   product,
+		Version:   strings.TrimPrefix(version, "v"),
+		Release:   rel.Name,
+		Snapshot:  rel.Snapshot,
+		Link:      link,
+		Advisory:  rel.Advisory,
+		Images:    rel.Images,
+		namespace: namespace,
+	}
+}
+
+// marker identifies the comments announcing the Release. It includes the enclosing braces, so that it does not match
+// the marker of a Release whose name starts with this Release's name.
+func (a *Announcement) marker() string {
+	return fmt.Sprintf("{{%s %s/%s}}", announcementMarker, a.namespace, a.Release)
+}
+
+// body renders the comment posted on each issue, ending with the marker
+func (a *Announcement) body() (string, error) {
+	rendered, err := templates.Render(templates.Announcement, a)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s\n\n%s", strings.TrimSpace(rendered), a.marker()), nil
+}
+
+// findAnnouncement returns the comment containing the given marker, or nil if there is none
+func findAnnouncement(comments []jira.Comment, marker string) *jira.Comment {
+	for i := range comments {
+		if strings.Contains(comments[i].Body, marker) {
+			return &comments[i]
+		}
+	}
+	return nil
+}
+
+// normalizeComment removes the differences Jira may introduce when storing a comment: surrounding whitespace and CRLF
+// line endings
+func normalizeComment(body string) string {
+	return strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+}
+
+// Post comments the announcement on each of the given issues. Issues which already have a comment announcing the
+// Release are not commented on again, and the existing comment is edited instead if its text differs, so that posting
+// can be rerun. If dryRun is true, the comment is printed and no changes are made. Each issue is attempted even if
+// others fail.
+func (a *Announcement) Post(issueKeys []string, dryRun bool) error {
+	body, err := a.body()
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("The following comment would be posted on %s:\n%s\n", strings.Join(issueKeys, ", "), body)
+		return nil
+	}
+	var errs []error
+	for _, key := range issueKeys {
+		comments, err := jira.GetComments(key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error listing comments of %s: %w", key, err))
+			continue
+		}
+		existing := findAnnouncement(comments, a.marker())
+		switch {
+		case existing == nil:
+			if _, err = jira.AddComment(key, body); err != nil {
+				errs = append(errs, fmt.Errorf("error commenting on %s: %w", key, err))
+				continue
+			}
+			fmt.Printf("Announced release on %s\n", key)
+		case normalizeComment(existing.Body) == normalizeComment(body):
+			fmt.Printf("Release already announced on %s\n", key)
+		default:
+			if _, err = jira.EditComment(key, existing.ID, body); err != nil {
+				errs = append(errs, fmt.Errorf("error editing comment %s of %s: %w", existing.ID, key, err))
+				continue
+			}
+			fmt.Printf("Updated release announcement on %s\n", key)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,51 @@
+package release
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sebsoto/gojira/pkg/jira"
+	"github.com/sebsoto/gojira/pkg/konflux"
+)
+
+func TestAnnouncement(t *testing.T) {
+	rel := &konflux.HistoryEntry{
+		Name:     "wmco-prod-abcde",
+		Snapshot: "wmco-snap",
+		Advisory: "https://errata.example.com/1",
+		Images:   []konflux.ComponentImage{{Component: "operator", Image: "quay.io/org/operator@sha256:1"}},
+	}
+	a := NewAnnouncement("Windows Machine Config Operator", "v10.19.1", "wmco-tenant", "https://konflux.example.com/r", rel)
+	body, err := a.body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"Windows Machine Config Operator 10.19.1 has shipped in Konflux Release [wmco-prod-abcde|https://konflux.example.com/r]",
+		"snapshot wmco-snap",
+		"Advisory: https://errata.example.com/1",
+		"* operator: quay.io/org/operator@sha256:1",
+		"gojira-announcement: wmco-tenant/wmco-prod-abcde",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected %q in comment:\n%s", expected, body)
+		}
+	}
+
+	comments := []jira.Comment{
+		{ID: "1", Body: "Verified on 4.19"},
+		{ID: "2", Body: "Shipped\n\n{{gojira-announcement: wmco-tenant/wmco-prod-other}}"},
+		{ID: "4", Body: "Shipped\n\n{{gojira-announcement: wmco-tenant/wmco-prod-abcde0}}"},
+		{ID: "3", Body: body},
+	}
+	if found := findAnnouncement(comments, a.marker()); found == nil || found.ID != "3" {
+		t.Errorf("expected comment 3, got %v", found)
+	}
+	if found := findAnnouncement(comments[:3], a.marker()); found != nil {
+		t.Errorf("expected no announcement, got %v", found)
+	}
+	stored := strings.ReplaceAll(body, "\n", "\r\n") + "\r\n"
+	if normalizeComment(stored) != normalizeComment(body) {
+		t.Errorf("expected comment stored with CRLF line endings to equal the rendered comment")
+	}
+}
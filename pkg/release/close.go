@@ -0,0 +1,108 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/pkg/jira"
+)
+
+// transition is a planned change to the status of an issue
+type transition struct {
+	key  string
+	from string
+	to   string
+}
+
+// closeTransitions returns the transitions closing a shipped release. The release task and epic are moved to the
+// closing status, and the included issues according to the product's mapping. Issues already in their target status,
+// and included issues whose status is not mapped, are left out.
+func closeTransitions(closing config.Closing, releaseIssues, includedIssues []jira.Issue) []transition {
+	var transitions []transition
+	for _, issue := range releaseIssues {
+		if status := issueStatus(&issue); !strings.EqualFold(status, closing.Status) {
+			transitions = append(transitions, transition{key: issue.Key, from: status, to: closing.Status})
+		}
+	}
+	for _, issue := range includedIssues {
+		status := issueStatus(&issue)
+		if target, ok := mappedStatus(closing.Issues, status); ok && !strings.EqualFold(target, status) {
+			transitions = append(transitions, transition{key: issue.Key, from: status, to: target})
+		}
+	}
+	return transitions
+}
+
+// mappedStatus returns the status the given status is mapped to. Statuses are matched case-insensitively, as
+// transitions are.
+func mappedStatus(mapping map[string]string, status string) (string, bool) {
+	for from, to := range mapping {
+		if strings.EqualFold(from, status) {
+			return to, true
+		}
+	}
+	return "", false
+}
+
+func issueStatus(issue *jira.Issue) string {
+	if issue.Fields.Status == nil {
+		return ""
+	}
+	return issue.Fields.Status.Name
+}
+
+// CloseRelease closes the release task and its epic, and moves the issues included in the release to the statuses
+// given by the product's closing settings, adding the given comment to each included issue moved. If epicKey is empty,
+// the epic the task is linked to is used. Each issue is attempted even if others fail, and issues already in their
+// target status are skipped, so that closing can be retried.
+func CloseRelease(product *config.Product, taskKey, epicKey string, issueKeys []string, comment string) error {
+	task, err := jira.GetIssue(taskKey)
+	if err != nil {
+		return fmt.Errorf("error getting release task %s: %w", taskKey, err)
+	}
+	if epicKey == "" {
+		epicKey = task.Fields.EpicLink
+	}
+	releaseIssues := []jira.Issue{*task}
+	if epicKey != "" {
+		epic, err := jira.GetIssue(epicKey)
+		if err != nil {
+			return fmt.Errorf("error getting release epic %s: %w", epicKey, err)
+		}
+		releaseIssues = append(releaseIssues, *epic)
+	} else {
+		fmt.Printf("Release task %s is not linked to an epic\n", taskKey)
+	}
+	var includedIssues []jira.Issue
+	if len(issueKeys) > 0 {
+		var missing []string
+		includedIssues, missing, err = jira.GetIssues(issueKeys)
+		if err != nil {
+			return err
+		}
+		for _, key := range missing {
+			fmt.Fprintf(os.Stderr, "WARNING: issue %s not found, skipping\n", key)
+		}
+	}
+
+	closing := product.Closing
+	var errs []error
+	for _, t := range closeTransitions(closing, releaseIssues, includedIssues) {
+		opts := &jira.TransitionOptions{}
+		if t.to == closing.Status {
+			opts.Resolution = closing.Resolution
+		}
+		if t.key != task.Key && t.key != epicKey {
+			opts.Comment = comment
+		}
+		if err = jira.TransitionIssue(t.key, t.to, opts); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Printf("Moved %s from %s to %s\n", t.key, t.from, t.to)
+	}
+	return errors.Join(errs...)
+}
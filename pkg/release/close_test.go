@@ -0,0 +1,45 @@
+package release
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/pkg/jira"
+)
+
+func TestCloseTransitions(t *testing.T) {
+	issueWithStatus := func(key, status string) jira.Issue {
+		issue := jira.Issue{Key: key}
+		issue.Fields.Status = &jira.Status{Name: status}
+		return issue
+	}
+	releaseIssues := []jira.Issue{issueWithStatus("WINC-1", "In Progress"), issueWithStatus("WINC-2", "Closed")}
+	includedIssues := []jira.Issue{
+		issueWithStatus("OCPBUGS-1", "ON_QA"),
+		issueWithStatus("OCPBUGS-2", "Verified"),
+		issueWithStatus("OCPBUGS-3", "POST"),
+	}
+	transitions := closeTransitions(config.DefaultClosing(), releaseIssues, includedIssues)
+	expected := []transition{
+		{key: "WINC-1", from: "In Progress", to: "Closed"},
+		{key: "OCPBUGS-1", from: "ON_QA", to: "Release Pending"},
+		{key: "OCPBUGS-2", from: "Verified", to: "Closed"},
+	}
+	if !slices.Equal(transitions, expected) {
+		t.Errorf("expected %v, got %v", expected, transitions)
+	}
+
+	// Statuses are matched case-insensitively, so issues already closed are not transitioned again
+	closing := config.DefaultClosing()
+	closing.Status = "closed"
+	closing.Issues = map[string]string{"verified": "closed"}
+	transitions = closeTransitions(closing, releaseIssues[1:], includedIssues[1:2])
+	if len(transitions) != 1 || transitions[0].key != "OCPBUGS-2" {
+		t.Errorf("expected only OCPBUGS-2 to be moved, got %v", transitions)
+	}
+	transitions = closeTransitions(closing, nil, []jira.Issue{issueWithStatus("OCPBUGS-4", "Closed")})
+	if len(transitions) != 0 {
+		t.Errorf("expected no transitions, got %v", transitions)
+	}
+}
@@ -0,0 +1,89 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/sebsoto/gojira/pkg/jira"
+)
+
+// FixVersionMismatch is an issue included in a release whose fix versions do not include the release's version
+type FixVersionMismatch struct {
+	Key string
+	// Current are the fix versions the issue has, empty if it has none
+	Current []string
+}
+
+// CheckFixVersions returns the issues whose fix versions do not include the expected version
+func CheckFixVersions(issues []*jira.Issue, expected string) []FixVersionMismatch {
+	var mismatches []FixVersionMismatch
+	for _, issue := range issues {
+		var current []string
+		for _, fixVersion := range issue.Fields.FixVersions {
+			current = append(current, fixVersion.Name)
+		}
+		if !slices.Contains(current, expected) {
+			mismatches = append(mismatches, FixVersionMismatch{Key: issue.Key, Current: current})
+		}
+	}
+	return mismatches
+}
+
+// issueProject returns the key of the project of the issue with the given key, e.g. WINC for WINC-1234
+func issueProject(key string) string {
+	project, _, _ := strings.Cut(key, "-")
+	return project
+}
+
+// ApplyFixVersion adds the expected version to the fix versions of each mismatched issue in the given project, keeping
+// its other fix versions. The version is created in the project first if it does not exist yet. Issues of other
+// projects, such as shared bug trackers, are left unchanged, and an error listing them is returned. Each issue is
+// attempted even if others fail.
+func ApplyFixVersion(mismatches []FixVersionMismatch, expected, project string) error {
+	var errs []error
+	var ensured bool
+	var skipped []string
+	for _, mismatch := range mismatches {
+		if issueProject(mismatch.Key) != project {
+			fmt.Fprintf(os.Stderr, "WARNING: not changing %s, which is not in project %s\n", mismatch.Key, project)
+			skipped = append(skipped, mismatch.Key)
+			continue
+		}
+		if !ensured {
+			if err := ensureVersion(project, expected); err != nil {
+				errs = append(errs, err)
+			}
+			ensured = true
+		}
+		fixVersions := append(slices.Clone(mismatch.Current), expected)
+		if err := jira.SetFixVersions(mismatch.Key, fixVersions); err != nil {
+			errs = append(errs, fmt.Errorf("error setting fix version of %s: %w", mismatch.Key, err))
+			continue
+		}
+		fmt.Printf("Added fix version %s to %s\n", expected, mismatch.Key)
+	}
+	if len(skipped) > 0 {
+		errs = append(errs, fmt.Errorf("issues outside project %s do not have fix version %s: %s", project, expected,
+			strings.Join(skipped, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+// ensureVersion creates the named version in the project if it does not exist
+func ensureVersion(project, name string) error {
+	versions, err := jira.GetVersions(project)
+	if err != nil {
+		return fmt.Errorf("error listing versions of %s: %w", project, err)
+	}
+	if findVersion(versions, name) != nil {
+		return nil
+	}
+	if _, err = jira.CreateVersion(&jira.Version{Name: name, Project: project}); err != nil {
+		return fmt.Errorf("error creating version %s in %s: %w", name, project, err)
+	}
+	fmt.Printf("Created version %s in %s\n", name, project)
+	return nil
+}
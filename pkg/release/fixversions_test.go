@@ -0,0 +1,33 @@
+package release
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sebsoto/gojira/pkg/jira"
+)
+
+func TestCheckFixVersions(t *testing.T) {
+	issueWithVersions := func(key string, versions ...string) *jira.Issue {
+		issue := &jira.Issue{Key: key}
+		for _, v := range versions {
+			issue.Fields.FixVersions = append(issue.Fields.FixVersions, jira.FixVersion{Name: v})
+		}
+		return issue
+	}
+	mismatches := CheckFixVersions([]*jira.Issue{
+		issueWithVersions("WINC-1", "WMCO 10.19.1"),
+		issueWithVersions("WINC-2"),
+		issueWithVersions("OCPBUGS-3", "WMCO 10.19.0"),
+		issueWithVersions("OCPBUGS-4", "WMCO 10.18.3", "WMCO 10.19.1"),
+	}, "WMCO 10.19.1")
+	if len(mismatches) != 2 {
+		t.Fatalf("expected 2 mismatches, got %v", mismatches)
+	}
+	if mismatches[0].Key != "WINC-2" || len(mismatches[0].Current) != 0 {
+		t.Errorf("unexpected mismatch %v", mismatches[0])
+	}
+	if mismatches[1].Key != "OCPBUGS-3" || !slices.Equal(mismatches[1].Current, []string{"WMCO 10.19.0"}) {
+		t.Errorf("unexpected mismatch %v", mismatches[1])
+	}
+}
@@ -1,75 +1,79 @@
 package release
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"text/template"
 	"time"
 
 	"sigs.k8s.io/yaml"
 
 	releasev1alpha1 "github.com/konflux-ci/release-service/api/v1alpha1"
 
+	"github.com/sebsoto/gojira/pkg/config"
 	"github.com/sebsoto/gojira/pkg/jira"
+	"github.com/sebsoto/gojira/templates"
 )
 
+// release holds the values the issue templates are rendered with
 type release struct {
-	Zstream      bool
-	HandoverDate string
-	GADate       string
-	Version      string
-	Project      string
-	Release      string
+	Zstream    bool
+	EngFreeze  string
+	QEHandover string
+	QEStart    string
+	QEEnd      string
+	GA         string
+	Version    string
+	Project    string
+	Release    string
+	product    *config.Product
 }
 
 func formattedDate(t time.Time) string {
 	return t.Format(time.DateOnly)
 }
 
-func roundDownToWeekday(t time.Time) time.Time {
-	if t.Weekday() == time.Sunday {
-		return t.Add(2 * -24 * time.Hour)
-	} else if t.Weekday() == time.Saturday {
-		return t.Add(-24 * time.Hour)
+func newRelease(zstream bool, version string, releaseDate time.Time, product *config.Product, konfluxRelease *releasev1alpha1.Release) (*release, error) {
+	schedule, err := ProductSchedule(product, zstream, releaseDate)
+	if err != nil {
+		return nil, err
 	}
-	return t
-}
-
-func newRelease(patch bool, version string, releaseDate time.Time, project string, konfluxRelease *releasev1alpha1.Release) *release {
-	day := 24 * time.Hour
-	qePeriod := 10 * day
-	if patch {
-		qePeriod = 7 * day
-	}
-	qeEndDate := roundDownToWeekday(releaseDate.Add(-day))
-	qeHandoverDate := roundDownToWeekday(qeEndDate.Add(-qePeriod))
 	releaseString, _ := yaml.Marshal(konfluxRelease)
 	return &release{
-		HandoverDate: formattedDate(qeHandoverDate),
-		GADate:       formattedDate(releaseDate),
-		Version:      version,
-		Zstream:      patch,
-		Project:      project,
-		Release:      string(releaseString),
-	}
+		EngFreeze:  formattedDate(schedule.EngFreeze),
+		QEHandover: formattedDate(schedule.QEHandover),
+		QEStart:    formattedDate(schedule.QEStart),
+		QEEnd:      formattedDate(schedule.QEEnd),
+		GA:         formattedDate(schedule.GA),
+		Version:    version,
+		Zstream:    zstream,
+		Project:    product.JiraProject,
+		Release:    string(releaseString),
+		product:    product,
+	}, nil
 }
 
 // createReleaseEpic creates the release epic, and returns the key, e.g. WINC-1111
 func (r *release) createReleaseEpic() (string, error) {
-	t, err := template.New("epic_template").ParseFiles("/home/sebsoto/code/openshift/gojira/templates/epic_template")
+	epicDescription, err := templates.Render(templates.Epic, r)
 	if err != nil {
 		return "", err
 	}
-	epicDescription := new(bytes.Buffer)
-	err = t.Execute(epicDescription, r)
+	summary, err := config.Render(r.product.EpicSummary, r.Version)
+	if err != nil {
+		return "", err
+	}
+	epicName, err := config.Render(r.product.EpicName, r.Version)
+	if err != nil {
+		return "", err
+	}
+	versionName, err := config.Render(r.product.VersionName, r.Version)
 	if err != nil {
 		return "", err
 	}
 	newIssue := jira.Issue{
 		Fields: jira.IssueFields{
-			Summary:     fmt.Sprintf("Windows Machine Config Operator %s Release", r.Version),
-			Description: epicDescription.String(),
+			Summary:     summary,
+			Description: epicDescription,
 			Project: jira.Project{
 				ID:  nil,
 				Key: &r.Project,
@@ -77,17 +81,17 @@ func (r *release) createReleaseEpic() (string, error) {
 			IssueType: jira.IssueType{Name: jira.EpicIssue},
 			TargetVersion: []jira.TargetVersion{
 				{
-					Name: fmt.Sprintf("WMCO %s", r.Version),
+					Name: versionName,
 				},
 			},
-			Security: &jira.Security{
-				Name: "Red Hat Employee",
-			},
-			EpicName: fmt.Sprintf("WMCO %s Release", r.Version),
-			Labels:   []string{"OperatorProductization"},
-			Priority: &jira.Priority{Name: jira.MajorPriority},
+			EpicName: epicName,
+			Labels:   r.product.Labels,
+			Priority: &jira.Priority{Name: jira.IssuePriorityName(r.product.Priority)},
 		},
 	}
+	if r.product.SecurityLevel != "" {
+		newIssue.Fields.Security = &jira.Security{Name: r.product.SecurityLevel}
+	}
 	response, err := jira.CreateIssue(&newIssue)
 	if err != nil {
 		return "", err
@@ -96,35 +100,37 @@ func (r *release) createReleaseEpic() (string, error) {
 }
 
 func (r *release) createReleaseTask(epicTicketID string) error {
-	t, err := template.New("release_task_template").ParseFiles("/home/sebsoto/code/openshift/gojira/templates/release_task_template")
+	description, err := templates.Render(templates.ReleaseTask, r)
 	if err != nil {
 		return err
 	}
-	description := new(bytes.Buffer)
-	err = t.Execute(description, r)
+	summary, err := config.Render(r.product.TaskSummary, r.Version)
 	if err != nil {
 		return err
 	}
 	newStory := jira.Issue{
 		Fields: jira.IssueFields{
-			Summary:     fmt.Sprintf("Red Hat OpenShift for Windows Containers %s Release", r.Version),
-			Description: description.String(),
+			Summary:     summary,
+			Description: description,
 			Project: jira.Project{
 				ID:  nil,
 				Key: &r.Project,
 			},
 			IssueType: jira.IssueType{Name: jira.TaskIssue},
 			EpicLink:  epicTicketID,
-			Labels:    []string{"docs", "qe", "release"},
-			Priority:  &jira.Priority{Name: jira.MajorPriority},
+			Labels:    r.product.TaskLabels,
+			Priority:  &jira.Priority{Name: jira.IssuePriorityName(r.product.Priority)},
 		},
 	}
 	_, err = jira.CreateIssue(&newStory)
 	return err
 }
 
-func CreateIssues(jiraProject, version string, majorRelease bool, releaseDate time.Time, release *releasev1alpha1.Release) error {
-	r := newRelease(majorRelease, version, releaseDate, jiraProject, release)
+func CreateIssues(product *config.Product, version string, majorRelease bool, releaseDate time.Time, release *releasev1alpha1.Release) error {
+	r, err := newRelease(!majorRelease, version, releaseDate, product, release)
+	if err != nil {
+		return err
+	}
 	epicKey, err := r.createReleaseEpic()
 	if err != nil {
 		return err
@@ -144,18 +150,16 @@ type fields struct {
 	Description string `json:"description"`
 }
 
-func UpdateRelease(issue, jiraProject, version string, majorRelease bool, releaseDate time.Time, release *releasev1alpha1.Release) error {
-	r := newRelease(majorRelease, version, releaseDate, jiraProject, release)
-	t, err := template.New("release_task_template").ParseFiles("/home/sebsoto/code/openshift/gojira/templates/release_task_template")
+func UpdateRelease(issue string, product *config.Product, version string, majorRelease bool, releaseDate time.Time, release *releasev1alpha1.Release) error {
+	r, err := newRelease(!majorRelease, version, releaseDate, product, release)
 	if err != nil {
 		return err
 	}
-	description := new(bytes.Buffer)
-	err = t.Execute(description, r)
+	description, err := templates.Render(templates.ReleaseTask, r)
 	if err != nil {
 		return err
 	}
-	update := fieldsUpdater{fields{Description: description.String()}}
+	update := fieldsUpdater{fields{Description: description}}
 	updateBody, err := json.Marshal(update)
 	if err != nil {
 		return err
@@ -0,0 +1,127 @@
+package release
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sebsoto/gojira/pkg/config"
+)
+
+const day = 24 * time.Hour
+
+var (
+	// DefaultMajorOffsets are the milestone offsets used for major releases
+	DefaultMajorOffsets = config.ScheduleOffsets{QEEnd: 1, QEPeriod: 10, QEStartDelay: 0, EngFreeze: 7}
+	// DefaultZStreamOffsets are the milestone offsets used for z-stream releases
+	DefaultZStreamOffsets = config.ScheduleOffsets{QEEnd: 1, QEPeriod: 7, QEStartDelay: 0, EngFreeze: 3}
+)
+
+// Schedule holds the dates of the milestones of a release
+type Schedule struct {
+	EngFreeze  time.Time
+	QEHandover time.Time
+	QEStart    time.Time
+	QEEnd      time.Time
+	GA         time.Time
+}
+
+// Calendar knows which days are working days
+type Calendar struct {
+	holidays map[string]bool
+}
+
+// LoadCalendar returns a calendar with the holidays listed in the given file. Each line of the file holds a date in the
+// format YYYY-MM-DD, optionally followed by a description. Empty lines and lines starting with # are ignored. If path
+// is empty, only weekends are non-working days.
+func LoadCalendar(path string) (*Calendar, error) {
+	c := &Calendar{holidays: make(map[string]bool)}
+	if path == "" {
+		return c, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		date, err := time.Parse(time.DateOnly, strings.Fields(line)[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+		}
+		c.holidays[formattedDate(date)] = true
+	}
+	return c, scanner.Err()
+}
+
+// IsWorkingDay returns true if the given day is neither a weekend nor a holiday
+func (c *Calendar) IsWorkingDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.holidays[formattedDate(t)]
+}
+
+// RoundDown returns the closest working day on or before the given day
+func (c *Calendar) RoundDown(t time.Time) time.Time {
+	for !c.IsWorkingDay(t) {
+		t = t.Add(-day)
+	}
+	return t
+}
+
+// RoundUp returns the closest working day on or after the given day
+func (c *Calendar) RoundUp(t time.Time) time.Time {
+	for !c.IsWorkingDay(t) {
+		t = t.Add(day)
+	}
+	return t
+}
+
+// NewSchedule computes the milestones of a release going GA on the given date
+func NewSchedule(ga time.Time, offsets config.ScheduleOffsets, calendar *Calendar) *Schedule {
+	qeEnd := calendar.RoundDown(ga.Add(time.Duration(-offsets.QEEnd) * day))
+	qeHandover := calendar.RoundDown(qeEnd.Add(time.Duration(-offsets.QEPeriod) * day))
+	return &Schedule{
+		EngFreeze:  calendar.RoundDown(qeHandover.Add(time.Duration(-offsets.EngFreeze) * day)),
+		QEHandover: qeHandover,
+		QEStart:    calendar.RoundUp(qeHandover.Add(time.Duration(offsets.QEStartDelay) * day)),
+		QEEnd:      qeEnd,
+		GA:         ga,
+	}
+}
+
+// ProductSchedule computes the milestones of a release of the given product going GA on the given date
+func ProductSchedule(product *config.Product, zstream bool, ga time.Time) (*Schedule, error) {
+	calendar, err := LoadCalendar(product.Schedule.Holidays)
+	if err != nil {
+		return nil, err
+	}
+	offsets := DefaultMajorOffsets
+	if product.Schedule.Major != nil {
+		offsets = *product.Schedule.Major
+	}
+	if zstream {
+		offsets = DefaultZStreamOffsets
+		if product.Schedule.ZStream != nil {
+			offsets = *product.Schedule.ZStream
+		}
+	}
+	return NewSchedule(ga, offsets, calendar), nil
+}
+
+// Print writes the schedule as a table to stdout
+func (s *Schedule) Print() {
+	fmt.Printf("Engineering Code Freeze:    %s\n", formattedDate(s.EngFreeze))
+	fmt.Printf("Final Build, errata ON_QA:  %s\n", formattedDate(s.QEHandover))
+	fmt.Printf("QE Testing Start:           %s\n", formattedDate(s.QEStart))
+	fmt.Printf("QE Testing End:             %s\n", formattedDate(s.QEEnd))
+	fmt.Printf("SP Push (GA):               %s\n", formattedDate(s.GA))
+}
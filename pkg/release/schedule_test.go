@@ -0,0 +1,77 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/templates"
+)
+
+func TestNewSchedule(t *testing.T) {
+	holidays := filepath.Join(t.TempDir(), "holidays")
+	if err := os.WriteFile(holidays, []byte("# US holidays\n2025-05-26 Memorial Day\n\n2025-06-19\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	calendar, err := LoadCalendar(holidays)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name     string
+		ga       string
+		offsets  config.ScheduleOffsets
+		expected []string
+	}{
+		{
+			name:     "major",
+			ga:       "2025-06-10",
+			offsets:  DefaultMajorOffsets,
+			expected: []string{"2025-05-23", "2025-05-30", "2025-05-30", "2025-06-09", "2025-06-10"},
+		},
+		{
+			name:     "z-stream over a weekend",
+			ga:       "2025-06-16",
+			offsets:  DefaultZStreamOffsets,
+			expected: []string{"2025-06-03", "2025-06-06", "2025-06-06", "2025-06-13", "2025-06-16"},
+		},
+		{
+			name:     "holidays",
+			ga:       "2025-06-20",
+			offsets:  config.ScheduleOffsets{QEEnd: 1, QEPeriod: 24, QEStartDelay: 1, EngFreeze: 1},
+			expected: []string{"2025-05-22", "2025-05-23", "2025-05-27", "2025-06-18", "2025-06-20"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ga, err := time.Parse(time.DateOnly, tc.ga)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := NewSchedule(ga, tc.offsets, calendar)
+			actual := []string{formattedDate(s.EngFreeze), formattedDate(s.QEHandover), formattedDate(s.QEStart),
+				formattedDate(s.QEEnd), formattedDate(s.GA)}
+			if strings.Join(actual, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEpicTemplate(t *testing.T) {
+	ga, _ := time.Parse(time.DateOnly, "2025-06-10")
+	r, err := newRelease(false, "10.19.0", ga, config.DefaultProduct(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := templates.Render(templates.Epic, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Major release") || !strings.Contains(out, "QE Testing End: 2025-06-09") {
+		t.Errorf("unexpected epic description: %s", out)
+	}
+}
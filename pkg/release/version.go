@@ -0,0 +1,170 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sebsoto/gojira/pkg/config"
+	"github.com/sebsoto/gojira/pkg/jira"
+)
+
+// findVersion returns the version with the given name, or nil if there is none
+func findVersion(versions []jira.Version, name string) *jira.Version {
+	for i := range versions {
+		if versions[i].Name == name {
+			return &versions[i]
+		}
+	}
+	return nil
+}
+
+// CreateProjectVersion creates the Jira version tracking the given release of the product, in the product's project.
+// The version starts at the engineering code freeze of the release's schedule, and is released on the GA date.
+func CreateProjectVersion(product *config.Product, version string, zstream bool, ga time.Time,
+	description string) (*jira.Version, error) {
+	name, err := config.Render(product.VersionName, version)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := ProductSchedule(product, zstream, ga)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := jira.GetVersions(product.JiraProject)
+	if err != nil {
+		return nil, err
+	}
+	if findVersion(versions, name) != nil {
+		return nil, fmt.Errorf("version %s already exists in %s", name, product.JiraProject)
+	}
+	return jira.CreateVersion(&jira.Version{
+		Name:        name,
+		Description: description,
+		Project:     product.JiraProject,
+		StartDate:   formattedDate(schedule.EngFreeze),
+		ReleaseDate: formattedDate(schedule.GA),
+	})
+}
+
+// VersionsReleasedBefore returns the released versions which are not archived, and were released before the given day
+func VersionsReleasedBefore(versions []jira.Version, day time.Time) []jira.Version {
+	var old []jira.Version
+	for _, v := range versions {
+		if !v.Released || v.Archived || v.ReleaseDate == "" {
+			continue
+		}
+		if releaseDate, err := time.Parse(time.DateOnly, v.ReleaseDate); err == nil && releaseDate.Before(day) {
+			old = append(old, v)
+		}
+	}
+	return old
+}
+
+// ArchiveVersions archives the named versions of the project. Each version is attempted even if others fail.
+func ArchiveVersions(project string, names []string) error {
+	versions, err := jira.GetVersions(project)
+	if err != nil {
+		return err
+	}
+	archived := true
+	var errs []error
+	for _, name := range names {
+		v := findVersion(versions, name)
+		if v == nil {
+			errs = append(errs, fmt.Errorf("version %s not found in %s", name, project))
+			continue
+		}
+		if v.Archived {
+			fmt.Printf("Version %s is already archived\n", name)
+			continue
+		}
+		if _, err = jira.UpdateVersion(v.ID, &jira.VersionUpdate{Archived: &archived}); err != nil {
+			errs = append(errs, fmt.Errorf("error archiving version %s: %w", name, err))
+			continue
+		}
+		fmt.Printf("Archived version %s\n", name)
+	}
+	return errors.Join(errs...)
+}
+
+// nextVersion returns the first version after the named one, in the project's order, which is neither released nor
+// archived
+func nextVersion(versions []jira.Version, name string) *jira.Version {
+	i := slices.IndexFunc(versions, func(v jira.Version) bool { return v.Name == name })
+	if i < 0 {
+		return nil
+	}
+	for j := i + 1; j < len(versions); j++ {
+		if !versions[j].Released && !versions[j].Archived {
+			return &versions[j]
+		}
+	}
+	return nil
+}
+
+// replaceVersion returns the names of the fix versions with old replaced by replacement
+func replaceVersion(fixVersions []jira.FixVersion, old, replacement string) []string {
+	var names []string
+	for _, fixVersion := range fixVersions {
+		name := fixVersion.Name
+		if name == old {
+			name = replacement
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ReleaseProjectVersion marks the named version of the project as released on the given day. Unresolved issues with
+// the version as a fix version are first moved to the next version, which is the version named next if given, or the
+// next unreleased version of the project otherwise.
+func ReleaseProjectVersion(project, name, next string, day time.Time) error {
+	versions, err := jira.GetVersions(project)
+	if err != nil {
+		return err
+	}
+	v := findVersion(versions, name)
+	if v == nil {
+		return fmt.Errorf("version %s not found in %s", name, project)
+	}
+	query := fmt.Sprintf(`project = %s AND fixVersion = "%s" AND resolution = Unresolved`, project,
+		strings.ReplaceAll(name, `"`, `\"`))
+	unresolved, err := jira.DefaultClient.SearchWithOptions(query, &jira.SearchOptions{Fields: []string{"fixVersions"}})
+	if err != nil {
+		return err
+	}
+	if len(unresolved) > 0 {
+		var target *jira.Version
+		if next != "" {
+			if target = findVersion(versions, next); target == nil {
+				return fmt.Errorf("version %s not found in %s", next, project)
+			}
+		} else if target = nextVersion(versions, name); target == nil {
+			return fmt.Errorf("%d unresolved issues have fix version %s, and there is no later unreleased version to "+
+				"move them to", len(unresolved), name)
+		}
+		for _, issue := range unresolved {
+			err = jira.SetFixVersions(issue.Key, replaceVersion(issue.Fields.FixVersions, name, target.Name))
+			if err != nil {
+				return fmt.Errorf("error moving %s to %s: %w", issue.Key, target.Name, err)
+			}
+			fmt.Printf("Moved unresolved issue %s to %s\n", issue.Key, target.Name)
+		}
+	}
+	if v.Released {
+		fmt.Printf("Version %s is already released\n", name)
+		return nil
+	}
+	released := true
+	_, err = jira.UpdateVersion(v.ID, &jira.VersionUpdate{Released: &released, ReleaseDate: formattedDate(day)})
+	if err != nil {
+		return fmt.Errorf("error releasing version %s: %w", name, err)
+	}
+	fmt.Printf("Released version %s\n", name)
+	return nil
+}
@@ -0,0 +1,47 @@
+package release
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sebsoto/gojira/pkg/jira"
+)
+
+func TestVersionsReleasedBefore(t *testing.T) {
+	versions := []jira.Version{
+		{Name: "WMCO 10.17.0", Released: true, Archived: true, ReleaseDate: "2024-10-01"},
+		{Name: "WMCO 10.18.0", Released: true, ReleaseDate: "2025-02-01"},
+		{Name: "WMCO 10.19.0", Released: true, ReleaseDate: "2025-06-10"},
+		{Name: "WMCO 10.19.1", ReleaseDate: "2025-05-01"},
+	}
+	day, _ := time.Parse(time.DateOnly, "2025-06-01")
+	var names []string
+	for _, v := range VersionsReleasedBefore(versions, day) {
+		names = append(names, v.Name)
+	}
+	if !slices.Equal(names, []string{"WMCO 10.18.0"}) {
+		t.Errorf("unexpected versions %v", names)
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	versions := []jira.Version{
+		{Name: "WMCO 10.19.0", Released: true},
+		{Name: "WMCO 10.19.1"},
+		{Name: "WMCO 10.19.2", Archived: true},
+		{Name: "WMCO 10.19.3"},
+	}
+	if next := nextVersion(versions, "WMCO 10.19.1"); next == nil || next.Name != "WMCO 10.19.3" {
+		t.Errorf("unexpected next version %v", next)
+	}
+	if next := nextVersion(versions, "WMCO 10.19.3"); next != nil {
+		t.Errorf("expected no next version, got %v", next)
+	}
+
+	fixVersions := []jira.FixVersion{{Name: "WMCO 10.19.1"}, {Name: "WMCO 10.18.4"}, {Name: "WMCO 10.19.3"}}
+	replaced := replaceVersion(fixVersions, "WMCO 10.19.1", "WMCO 10.19.3")
+	if !slices.Equal(replaced, []string{"WMCO 10.19.3", "WMCO 10.18.4"}) {
+		t.Errorf("unexpected fix versions %v", replaced)
+	}
+}
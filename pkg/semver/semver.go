@@ -1,37 +1,189 @@
 package semver
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 )
 
+// Semver is a semantic version as described by https://semver.org/spec/v2.0.0.html
 type Semver struct {
 	Major int
 	Minor int
 	Patch int
+	// Prerelease holds the dot separated pre-release identifiers, e.g. ["rc", "1"] for 1.2.3-rc.1
+	Prerelease []string
+	// Build holds the dot separated build metadata identifiers, e.g. ["build", "5"] for 1.2.3+build.5
+	Build []string
 }
 
+// New parses a semver of the format [v]X.Y.Z[-prerelease][+build]. For convenience, a version without a patch number,
+// such as v10.19, is accepted and treated as X.Y.0.
 func New(semver string) (*Semver, error) {
-	semverSplit := strings.Split(semver, ".")
-	if len(semverSplit) != 3 {
+	var s Semver
+	var err error
+	version, build, hasBuild := strings.Cut(strings.TrimPrefix(semver, "v"), "+")
+	if hasBuild {
+		if s.Build, err = parseIdentifiers(build, false); err != nil {
+			return nil, fmt.Errorf("invalid build metadata in %s: %w", semver, err)
+		}
+	}
+	version, prerelease, hasPrerelease := strings.Cut(version, "-")
+	if hasPrerelease {
+		if s.Prerelease, err = parseIdentifiers(prerelease, true); err != nil {
+			return nil, fmt.Errorf("invalid pre-release in %s: %w", semver, err)
+		}
+	}
+	semverSplit := strings.Split(version, ".")
+	if len(semverSplit) != 2 && len(semverSplit) != 3 {
 		return nil, fmt.Errorf("expected a semver of format vX.Y.Z")
 	}
-	major, err := strconv.Atoi(strings.TrimPrefix(semverSplit[0], "v"))
-	if err != nil {
+	if s.Major, err = parseNumber(semverSplit[0]); err != nil {
 		return nil, err
 	}
-	minor, err := strconv.Atoi(semverSplit[1])
-	if err != nil {
+	if s.Minor, err = parseNumber(semverSplit[1]); err != nil {
 		return nil, err
 	}
-	patch, err := strconv.Atoi(semverSplit[2])
-	if err != nil {
-		return nil, err
+	if len(semverSplit) == 3 {
+		if s.Patch, err = parseNumber(semverSplit[2]); err != nil {
+			return nil, err
+		}
+	}
+	return &s, nil
+}
+
+// parseNumber parses a numeric identifier, which may not have leading zeros
+func parseNumber(n string) (int, error) {
+	if n == "" || !isNumeric(n) {
+		return 0, fmt.Errorf("expected a number, got %q", n)
 	}
-	return &Semver{
-		Major: major,
-		Minor: minor,
-		Patch: patch,
-	}, nil
+	if len(n) > 1 && n[0] == '0' {
+		return 0, fmt.Errorf("numeric identifier %s has a leading zero", n)
+	}
+	return strconv.Atoi(n)
+}
+
+// parseIdentifiers splits dot separated pre-release or build identifiers, validating each one
+func parseIdentifiers(s string, prerelease bool) ([]string, error) {
+	identifiers := strings.Split(s, ".")
+	for _, identifier := range identifiers {
+		if identifier == "" {
+			return nil, fmt.Errorf("empty identifier")
+		}
+		for _, c := range identifier {
+			if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && c != '-' {
+				return nil, fmt.Errorf("identifier %s contains invalid character %q", identifier, c)
+			}
+		}
+		if prerelease && isNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return nil, fmt.Errorf("numeric identifier %s has a leading zero", identifier)
+		}
+	}
+	return identifiers, nil
+}
+
+func isNumeric(identifier string) bool {
+	for _, c := range identifier {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the version in the format X.Y.Z[-prerelease][+build], without a leading v
+func (s Semver) String() string {
+	version := fmt.Sprintf("%d.%d.%d", s.Major, s.Minor, s.Patch)
+	if len(s.Prerelease) > 0 {
+		version += "-" + strings.Join(s.Prerelease, ".")
+	}
+	if len(s.Build) > 0 {
+		version += "+" + strings.Join(s.Build, ".")
+	}
+	return version
+}
+
+// IsPrerelease returns true if the version has pre-release identifiers
+func (s Semver) IsPrerelease() bool {
+	return len(s.Prerelease) > 0
+}
+
+// Compare returns -1, 0 or 1 if s has a lower, equal or higher precedence than other. Build metadata does not affect
+// precedence.
+func (s Semver) Compare(other Semver) int {
+	if c := cmp.Compare(s.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(s.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(s.Patch, other.Patch); c != 0 {
+		return c
+	}
+	// A version without pre-release identifiers has a higher precedence than one with them
+	switch {
+	case len(s.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(s.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(s.Prerelease) && i < len(other.Prerelease); i++ {
+		if c := compareIdentifier(s.Prerelease[i], other.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(s.Prerelease), len(other.Prerelease))
+}
+
+// compareIdentifier compares pre-release identifiers. Numeric identifiers are compared numerically and have a lower
+// precedence than alphanumeric identifiers, which are compared lexically.
+func compareIdentifier(a, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+	switch {
+	case aNumeric && bNumeric:
+		aInt, _ := strconv.Atoi(a)
+		bInt, _ := strconv.Atoi(b)
+		return cmp.Compare(aInt, bInt)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// Less returns true if s has a lower precedence than other
+func (s Semver) Less(other Semver) bool {
+	return s.Compare(other) < 0
+}
+
+// Equal returns true if s and other have the same precedence, ignoring build metadata
+func (s Semver) Equal(other Semver) bool {
+	return s.Compare(other) == 0
+}
+
+// Sort sorts the given versions in ascending order of precedence
+func Sort(versions []Semver) {
+	slices.SortStableFunc(versions, func(a, b Semver) int {
+		return a.Compare(b)
+	})
+}
+
+// BumpMajor returns the next major version, e.g. 2.0.0 for 1.2.3
+func (s Semver) BumpMajor() Semver {
+	return Semver{Major: s.Major + 1}
+}
+
+// BumpMinor returns the next minor version, e.g. 1.3.0 for 1.2.3
+func (s Semver) BumpMinor() Semver {
+	return Semver{Major: s.Major, Minor: s.Minor + 1}
+}
+
+// BumpPatch returns the next patch version, e.g. 1.2.4 for 1.2.3
+func (s Semver) BumpPatch() Semver {
+	return Semver{Major: s.Major, Minor: s.Minor, Patch: s.Patch + 1}
 }
@@ -0,0 +1,123 @@
+package semver
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    string
+		expectedErr bool
+	}{
+		{input: "v10.19.0", expected: "10.19.0"},
+		{input: "1.2.3", expected: "1.2.3"},
+		{input: "10.19", expected: "10.19.0"},
+		{input: "v10.19.0-rc.1", expected: "10.19.0-rc.1"},
+		{input: "1.2.3+build", expected: "1.2.3+build"},
+		{input: "1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay", expected: "1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay"},
+		{input: "1.0.0-0A.is.legal", expected: "1.0.0-0A.is.legal"},
+		{input: "1.0.0+0.build.1-rc.10000aaa-kk-0.1", expected: "1.0.0+0.build.1-rc.10000aaa-kk-0.1"},
+		{input: "1.0.0-rc.1+build.007", expected: "1.0.0-rc.1+build.007"},
+		{input: "1", expectedErr: true},
+		{input: "1.2.3.4", expectedErr: true},
+		{input: "01.2.3", expectedErr: true},
+		{input: "1.02.3", expectedErr: true},
+		{input: "1.2.x", expectedErr: true},
+		{input: "1.2.-3", expectedErr: true},
+		{input: "1.2.3-", expectedErr: true},
+		{input: "1.2.3-rc..1", expectedErr: true},
+		{input: "1.2.3-rc.01", expectedErr: true},
+		{input: "1.2.3+", expectedErr: true},
+		{input: "1.2.3+build$", expectedErr: true},
+		{input: "", expectedErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			s, err := New(tc.input)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s", s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.String() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, s)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Ordered by precedence as given in the semver 2.0 spec, section 11
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+		"10.19.3",
+		"11.0.0",
+	}
+	var versions []Semver
+	for _, v := range ordered {
+		s, err := New(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, *s)
+	}
+	for i := range versions {
+		for j := range versions {
+			expected := cmp.Compare(i, j)
+			if actual := versions[i].Compare(versions[j]); actual != expected {
+				t.Errorf("%s compared to %s: expected %d, got %d", versions[i], versions[j], expected, actual)
+			}
+		}
+	}
+
+	shuffled := slices.Clone(versions)
+	slices.Reverse(shuffled)
+	shuffled[0], shuffled[5] = shuffled[5], shuffled[0]
+	Sort(shuffled)
+	for i := range shuffled {
+		if !shuffled[i].Equal(versions[i]) {
+			t.Errorf("expected %s at index %d, got %s", versions[i], i, shuffled[i])
+		}
+	}
+}
+
+func TestEqualIgnoresBuild(t *testing.T) {
+	a, _ := New("1.2.3+build.1")
+	b, _ := New("1.2.3+build.2")
+	if !a.Equal(*b) || a.Less(*b) || b.Less(*a) {
+		t.Errorf("expected %s and %s to have equal precedence", a, b)
+	}
+}
+
+func TestBump(t *testing.T) {
+	s, err := New("v10.19.3-rc.1+build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if major := s.BumpMajor().String(); major != "11.0.0" {
+		t.Errorf("unexpected major bump %s", major)
+	}
+	if minor := s.BumpMinor().String(); minor != "10.20.0" {
+		t.Errorf("unexpected minor bump %s", minor)
+	}
+	if patch := s.BumpPatch().String(); patch != "10.19.4" {
+		t.Errorf("unexpected patch bump %s", patch)
+	}
+}
@@ -0,0 +1,132 @@
+package vuln
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// Modules maps the path of each module a Go module depends on to the version used
+type Modules map[string]string
+
+// ParseModules returns the dependencies of the module described by the given go.mod and go.sum files. The module
+// graph recorded in go.mod is complete for modules using Go 1.17 or later. For older modules, dependencies missing
+// from go.mod are taken from go.sum, using the highest version whose source was downloaded. Replacements are applied,
+// and modules replaced by a local directory are left out, as their version is unknown. goSum may be nil.
+func ParseModules(goMod, goSum []byte) (Modules, error) {
+	f, err := modfile.Parse("go.mod", goMod, nil)
+	if err != nil {
+		return nil, err
+	}
+	modules := make(Modules)
+	for _, require := range f.Require {
+		modules[require.Mod.Path] = require.Mod.Version
+	}
+	if goSum != nil && !completeGraph(f) {
+		sumModules, err := parseGoSum(goSum)
+		if err != nil {
+			return nil, err
+		}
+		for path, version := range sumModules {
+			if _, ok := modules[path]; !ok {
+				modules[path] = version
+			}
+		}
+	}
+	for _, replace := range f.Replace {
+		version, ok := modules[replace.Old.Path]
+		if !ok || (replace.Old.Version != "" && replace.Old.Version != version) {
+			continue
+		}
+		delete(modules, replace.Old.Path)
+		if replace.New.Version != "" {
+			modules[replace.New.Path] = replace.New.Version
+		}
+	}
+	return modules, nil
+}
+
+// completeGraph reports whether go.mod lists every module in the build, which is the case from Go 1.17. go.sum may then
+// hold versions which are not selected, and is not used.
+func completeGraph(f *modfile.File) bool {
+	return f.Go != nil && semver.Compare("v"+f.Go.Version, "v1.17") >= 0
+}
+
+// parseGoSum returns the highest version of each module whose source hash is recorded in go.sum. Entries for go.mod
+// files alone are ignored, as they do not mean the module's code is built.
+func parseGoSum(goSum []byte) (Modules, error) {
+	modules := make(Modules)
+	scanner := bufio.NewScanner(bytes.NewReader(goSum))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("malformed go.sum line: %s", scanner.Text())
+		}
+		path, version := fields[0], fields[1]
+		if strings.HasSuffix(version, "/go.mod") {
+			continue
+		}
+		if current, ok := modules[path]; !ok || semver.Compare(version, current) > 0 {
+			modules[path] = version
+		}
+	}
+	return modules, scanner.Err()
+}
+
+// ModuleChange is a change to the version of a dependency. Old is empty for added modules, and New for removed ones.
+type ModuleChange struct {
+	Path string `json:"path"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+func (c ModuleChange) String() string {
+	switch {
+	case c.Old == "":
+		return fmt.Sprintf("%s %s (added)", c.Path, c.New)
+	case c.New == "":
+		return fmt.Sprintf("%s %s (removed)", c.Path, c.Old)
+	}
+	return fmt.Sprintf("%s %s -> %s", c.Path, c.Old, c.New)
+}
+
+// ModuleDiff holds the dependency changes between two versions of a module
+type ModuleDiff struct {
+	Added   []ModuleChange `json:"added"`
+	Removed []ModuleChange `json:"removed"`
+	// Changed holds modules whose version was upgraded or downgraded
+	Changed []ModuleChange `json:"changed"`
+}
+
+// Diff returns the changes from the old to the new set of modules, sorted by module path
+func Diff(old, new Modules) ModuleDiff {
+	diff := ModuleDiff{Added: []ModuleChange{}, Removed: []ModuleChange{}, Changed: []ModuleChange{}}
+	for _, path := range sortedPaths(new) {
+		oldVersion, ok := old[path]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, ModuleChange{Path: path, New: new[path]})
+		case oldVersion != new[path]:
+			diff.Changed = append(diff.Changed, ModuleChange{Path: path, Old: oldVersion, New: new[path]})
+		}
+	}
+	for _, path := range sortedPaths(old) {
+		if _, ok := new[path]; !ok {
+			diff.Removed = append(diff.Removed, ModuleChange{Path: path, Old: old[path]})
+		}
+	}
+	return diff
+}
+
+func sortedPaths(modules Modules) []string {
+	return slices.Sorted(maps.Keys(modules))
+}
@@ -0,0 +1,174 @@
+package vuln
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// goEcosystem is the OSV ecosystem of Go modules
+const goEcosystem = "Go"
+
+// Entry is a vulnerability in the OSV format, see https://ossf.github.io/osv-schema/. Only the fields used to match Go
+// modules are decoded.
+type Entry struct {
+	ID       string     `json:"id"`
+	Aliases  []string   `json:"aliases,omitempty"`
+	Summary  string     `json:"summary,omitempty"`
+	Affected []Affected `json:"affected"`
+}
+
+type Affected struct {
+	Package Package `json:"package"`
+	Ranges  []Range `json:"ranges,omitempty"`
+}
+
+type Package struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event changes whether the versions following it are affected. Exactly one field is set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// CVEs returns the CVE IDs the vulnerability is known as
+func (e *Entry) CVEs() []string {
+	var cves []string
+	for _, id := range append([]string{e.ID}, e.Aliases...) {
+		if strings.HasPrefix(id, "CVE-") {
+			cves = append(cves, id)
+		}
+	}
+	return cves
+}
+
+// Affects returns true if the given version of the module is vulnerable
+func (e *Entry) Affects(module, version string) bool {
+	for _, affected := range e.Affected {
+		if affected.Package.Ecosystem != goEcosystem || affected.Package.Name != module {
+			continue
+		}
+		// An entry without ranges affects every version
+		if len(affected.Ranges) == 0 {
+			return true
+		}
+		for _, r := range affected.Ranges {
+			if r.Type == "SEMVER" && r.affects(version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// affects evaluates the events of the range, which are ordered by version, for the given version
+func (r Range) affects(version string) bool {
+	affected := false
+	for _, event := range r.Events {
+		switch {
+		case event.Introduced != "":
+			if event.Introduced == "0" || semver.Compare(version, "v"+event.Introduced) >= 0 {
+				affected = true
+			}
+		case event.Fixed != "":
+			if semver.Compare(version, "v"+event.Fixed) >= 0 {
+				affected = false
+			}
+		case event.LastAffected != "":
+			if semver.Compare(version, "v"+event.LastAffected) > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+// Database is a set of vulnerabilities affecting Go modules
+type Database struct {
+	// byModule holds the entries affecting each module
+	byModule map[string][]*Entry
+}
+
+// LoadDatabase reads every OSV entry in the given directory and its subdirectories, such as a copy of the Go
+// vulnerability database from https://vuln.go.dev. JSON files which are not OSV entries, such as indexes, are ignored.
+func LoadDatabase(dir string) (*Database, error) {
+	db := &Database{byModule: make(map[string][]*Entry)}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var entry Entry
+		if err = json.Unmarshal(data, &entry); err != nil || entry.ID == "" || len(entry.Affected) == 0 {
+			return nil
+		}
+		db.add(&entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading vulnerability database %s: %w", dir, err)
+	}
+	return db, nil
+}
+
+func (db *Database) add(entry *Entry) {
+	var modules []string
+	for _, affected := range entry.Affected {
+		if affected.Package.Ecosystem == goEcosystem {
+			modules = append(modules, affected.Package.Name)
+		}
+	}
+	slices.Sort(modules)
+	for _, module := range slices.Compact(modules) {
+		db.byModule[module] = append(db.byModule[module], entry)
+	}
+}
+
+// Vulnerability is a vulnerability found in a dependency
+type Vulnerability struct {
+	ID      string   `json:"id"`
+	CVEs    []string `json:"cves"`
+	Summary string   `json:"summary,omitempty"`
+	Module  string   `json:"module"`
+	Version string   `json:"version"`
+}
+
+// Vulnerabilities returns the vulnerabilities affecting the given modules
+func (db *Database) Vulnerabilities(modules Modules) []Vulnerability {
+	var found []Vulnerability
+	for _, module := range sortedPaths(modules) {
+		for _, entry := range db.byModule[module] {
+			if entry.Affects(module, modules[module]) {
+				found = append(found, Vulnerability{
+					ID:      entry.ID,
+					CVEs:    append([]string{}, entry.CVEs()...),
+					Summary: entry.Summary,
+					Module:  module,
+					Version: modules[module],
+				})
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,68 @@
+// Package vuln compares the Go dependencies of two versions of a module, and checks them against an offline database
+// of known vulnerabilities
+package vuln
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"slices"
+
+	"github.com/sebsoto/gojira/pkg/git"
+)
+
+// ModulesAt reads the dependencies of the Go module in the given directory of the repository, at the given ref. If the
+// directory has no go.mod, an error wrapping fs.ErrNotExist is returned.
+func ModulesAt(repo git.Repo, ref, dir string) (Modules, error) {
+	goMod, err := repo.ReadFile(ref, path.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	goSum, err := repo.ReadFile(ref, path.Join(dir, "go.sum"))
+	if errors.Is(err, fs.ErrNotExist) {
+		goSum = nil
+	} else if err != nil {
+		return nil, err
+	}
+	return ParseModules(goMod, goSum)
+}
+
+// Report describes the dependency changes between two versions of a module, and their effect on known vulnerabilities
+type Report struct {
+	Changes ModuleDiff `json:"changes"`
+	// Fixed are vulnerabilities affecting the previous version which no longer affect the current one
+	Fixed []Vulnerability `json:"fixed"`
+	// Introduced are vulnerabilities affecting the current version which did not affect the previous one
+	Introduced []Vulnerability `json:"introduced"`
+	// Remaining are vulnerabilities affecting both versions
+	Remaining []Vulnerability `json:"remaining"`
+}
+
+// Check compares the dependencies of the previous and current version of a module
+func (db *Database) Check(previous, current Modules) *Report {
+	before, after := db.Vulnerabilities(previous), db.Vulnerabilities(current)
+	report := &Report{
+		Changes:    Diff(previous, current),
+		Fixed:      []Vulnerability{},
+		Introduced: []Vulnerability{},
+		Remaining:  []Vulnerability{},
+	}
+	for _, v := range before {
+		if !slices.ContainsFunc(after, v.same) {
+			report.Fixed = append(report.Fixed, v)
+		}
+	}
+	for _, v := range after {
+		if slices.ContainsFunc(before, v.same) {
+			report.Remaining = append(report.Remaining, v)
+		} else {
+			report.Introduced = append(report.Introduced, v)
+		}
+	}
+	return report
+}
+
+// same returns true if both are the same vulnerability in the same module, regardless of the version affected
+func (v Vulnerability) same(other Vulnerability) bool {
+	return v.ID == other.ID && v.Module == other.Module
+}
@@ -0,0 +1,136 @@
+package vuln
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseModules(t *testing.T) {
+	goMod := []byte(`module github.com/openshift/windows-machine-config-operator
+
+go 1.16
+
+require (
+	github.com/pkg/errors v0.9.1
+	golang.org/x/net v0.22.0 // indirect
+	k8s.io/api v0.30.0
+	example.com/local v1.0.0
+)
+
+replace (
+	k8s.io/api => k8s.io/api v0.30.2
+	example.com/local => ../local
+)
+`)
+	goSum := []byte(`github.com/pkg/errors v0.9.1 h1:abc=
+github.com/pkg/errors v0.9.1/go.mod h1:abc=
+golang.org/x/text v0.13.0 h1:abc=
+golang.org/x/text v0.14.0 h1:abc=
+golang.org/x/text v0.15.0/go.mod h1:abc=
+golang.org/x/sys v0.1.0/go.mod h1:abc=
+`)
+	modules, err := ParseModules(goMod, goSum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Modules{
+		"github.com/pkg/errors": "v0.9.1",
+		"golang.org/x/net":      "v0.22.0",
+		"golang.org/x/text":     "v0.14.0",
+		"k8s.io/api":            "v0.30.2",
+	}
+	if !maps.Equal(modules, expected) {
+		t.Errorf("expected %v, got %v", expected, modules)
+	}
+
+	// From Go 1.17, go.sum entries missing from go.mod are versions which are not selected
+	goMod = bytes.Replace(goMod, []byte("go 1.16"), []byte("go 1.21.0"), 1)
+	if modules, err = ParseModules(goMod, goSum); err != nil {
+		t.Fatal(err)
+	}
+	delete(expected, "golang.org/x/text")
+	if !maps.Equal(modules, expected) {
+		t.Errorf("expected go.sum to be ignored, got %v", modules)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	diff := Diff(
+		Modules{"a.com/kept": "v1.0.0", "a.com/bumped": "v1.0.0", "a.com/removed": "v1.0.0"},
+		Modules{"a.com/kept": "v1.0.0", "a.com/bumped": "v1.1.0", "a.com/added": "v0.1.0"},
+	)
+	if !slices.Equal(diff.Added, []ModuleChange{{Path: "a.com/added", New: "v0.1.0"}}) {
+		t.Errorf("unexpected added modules %v", diff.Added)
+	}
+	if !slices.Equal(diff.Removed, []ModuleChange{{Path: "a.com/removed", Old: "v1.0.0"}}) {
+		t.Errorf("unexpected removed modules %v", diff.Removed)
+	}
+	if !slices.Equal(diff.Changed, []ModuleChange{{Path: "a.com/bumped", Old: "v1.0.0", New: "v1.1.0"}}) {
+		t.Errorf("unexpected changed modules %v", diff.Changed)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	entries := map[string]string{
+		// Fixed by the upgrade of golang.org/x/net
+		"ID/GO-2024-0001.json": `{"id":"GO-2024-0001","aliases":["CVE-2024-0001","GHSA-xxxx"],"affected":[` +
+			`{"package":{"name":"golang.org/x/net","ecosystem":"Go"},"ranges":[{"type":"SEMVER","events":` +
+			`[{"introduced":"0"},{"fixed":"0.23.0"}]}]}]}`,
+		// Introduced by the upgrade of golang.org/x/net
+		"ID/GO-2024-0002.json": `{"id":"GO-2024-0002","affected":[` +
+			`{"package":{"name":"golang.org/x/net","ecosystem":"Go"},"ranges":[{"type":"SEMVER","events":` +
+			`[{"introduced":"0.23.0"},{"last_affected":"0.24.0"}]}]}]}`,
+		// Affects both versions of github.com/pkg/errors
+		"ID/GO-2024-0003.json": `{"id":"GO-2024-0003","aliases":["CVE-2024-0003"],"affected":[` +
+			`{"package":{"name":"github.com/pkg/errors","ecosystem":"Go"}}]}`,
+		// Affects the standard library, which is not a module dependency
+		"ID/GO-2024-0004.json": `{"id":"GO-2024-0004","affected":[` +
+			`{"package":{"name":"stdlib","ecosystem":"Go"}}]}`,
+		"index/modules.json": `[{"path":"golang.org/x/net"}]`,
+	}
+	for name, contents := range entries {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db, err := LoadDatabase(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := db.Check(
+		Modules{"golang.org/x/net": "v0.22.0", "github.com/pkg/errors": "v0.9.1"},
+		Modules{"golang.org/x/net": "v0.23.0", "github.com/pkg/errors": "v0.9.1"},
+	)
+	ids := func(vulns []Vulnerability) []string {
+		var found []string
+		for _, v := range vulns {
+			found = append(found, v.ID)
+		}
+		return found
+	}
+	if fixed := ids(report.Fixed); !slices.Equal(fixed, []string{"GO-2024-0001"}) {
+		t.Errorf("unexpected fixed vulnerabilities %v", fixed)
+	}
+	if !slices.Equal(report.Fixed[0].CVEs, []string{"CVE-2024-0001"}) {
+		t.Errorf("unexpected CVEs %v", report.Fixed[0].CVEs)
+	}
+	if introduced := ids(report.Introduced); !slices.Equal(introduced, []string{"GO-2024-0002"}) {
+		t.Errorf("unexpected introduced vulnerabilities %v", introduced)
+	}
+	if remaining := ids(report.Remaining); !slices.Equal(remaining, []string{"GO-2024-0003"}) {
+		t.Errorf("unexpected remaining vulnerabilities %v", remaining)
+	}
+	if len(report.Changes.Changed) != 1 {
+		t.Errorf("unexpected changes %v", report.Changes)
+	}
+}
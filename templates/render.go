@@ -0,0 +1,91 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"text/template"
+)
+
+// Dirs are searched in order for a template before falling back to the default template embedded in the
+// binary. The user's config directory, e.g. ~/.config/gojira/templates, is searched after these.
+var Dirs []string
+
+// UserDir returns the directory in the user's config directory templates are looked for in
+func UserDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "gojira", "templates"), nil
+}
+
+// load returns the named template, using the first override found in the template search path
+func load(name string) (*template.Template, error) {
+	dirs := slices.Clone(Dirs)
+	if userDir, err := UserDir(); err == nil {
+		dirs = append(dirs, userDir)
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name)
+		contents, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		t, err := template.New(name).Parse(string(contents))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template %s: %w", path, err)
+		}
+		return t, nil
+	}
+	return template.New(name).ParseFS(FS, name)
+}
+
+// missingFieldRegex matches the error given when a template references a field the data does not have
+var missingFieldRegex = regexp.MustCompile(`can't evaluate field (\w+)`)
+
+// Render executes the named template with the given data
+func Render(name string, data any) (string, error) {
+	t, err := load(name)
+	if err != nil {
+		return "", err
+	}
+	out := new(bytes.Buffer)
+	if err = t.Execute(out, data); err != nil {
+		if match := missingFieldRegex.FindStringSubmatch(err.Error()); match != nil {
+			return "", fmt.Errorf("template %s references unknown field %s: %w", name, match[1], err)
+		}
+		return "", fmt.Errorf("error rendering template %s: %w", name, err)
+	}
+	return out.String(), nil
+}
+
+// Export writes the default templates to dir, so they can be customized. Existing files are only replaced if overwrite
+// is set.
+func Export(dir string, overwrite bool) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, name := range Names {
+		contents, err := FS.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, name)
+		if _, err = os.Stat(path); err == nil && !overwrite {
+			return fmt.Errorf("%s already exists", path)
+		}
+		if err = os.WriteFile(path, contents, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", path)
+	}
+	return nil
+}
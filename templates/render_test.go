@@ -0,0 +1,31 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplateOverride(t *testing.T) {
+	dir := t.TempDir()
+	Dirs = []string{dir}
+	defer func() { Dirs = nil }()
+
+	out, err := Render(ReleaseTask, map[string]any{"Version": "10.19.0", "Release": "kind: Release"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "version 10.19.0") {
+		t.Errorf("embedded template not used: %s", out)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, ReleaseTask), []byte("Release {{ .Version }} {{ .Missing }}"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Render(ReleaseTask, struct{ Version string }{Version: "10.19.0"})
+	if err == nil || !strings.Contains(err.Error(), "unknown field Missing") {
+		t.Errorf("expected error naming the missing field, got %v", err)
+	}
+}
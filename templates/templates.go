@@ -0,0 +1,22 @@
+// Package templates holds the default templates used for the descriptions of release issues, the release notes of
+// Konflux Releases and the comments announcing them, and renders them, preferring user provided overrides
+package templates
+
+import "embed"
+
+// FS contains the default templates, by file name
+//
+//go:embed epic_template release_task_template release_notes_template announcement_template
+var FS embed.FS
+
+const (
+	Epic        = "epic_template"
+	ReleaseTask = "release_task_template"
+	// ReleaseNotes renders a YAML document with the synopsis, topic, description and solution of a Konflux Release
+	ReleaseNotes = "release_notes_template"
+	// Announcement renders the comment posted on the issues shipped by a Konflux Release
+	Announcement = "announcement_template"
+)
+
+// Names are the names of all default templates
+var Names = []string{Epic, ReleaseTask, ReleaseNotes, Announcement}